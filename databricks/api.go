@@ -0,0 +1,124 @@
+package databricks
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+// apiRequest describes a call to an endpoint of the Databricks REST API that
+// is not covered by the go-databricks clients yet.
+type apiRequest struct {
+	// Client and BaseURI are taken from the go-databricks client for the
+	// same service, so the call shares its authorizer and user agent.
+	Client  autorest.Client
+	BaseURI string
+
+	// PackageName and Method are only used to annotate errors.
+	PackageName string
+	Method      string
+
+	HTTPMethod string
+	Path       string
+	Query      map[string]interface{}
+	Body       interface{}
+
+	// BodyReader is streamed as the body instead of encoding Body as JSON,
+	// with the given ContentType. Such requests are not retried, as the body
+	// cannot be read twice.
+	BodyReader  io.Reader
+	ContentType string
+
+	// Output receives the raw response body instead of it being decoded.
+	Output io.Writer
+
+	// StatusCodes are the successful status codes. Defaults to 200.
+	StatusCodes []int
+}
+
+// send sends the request and decodes the JSON response into result, unless
+// result is nil.
+func (r apiRequest) send(ctx context.Context, result interface{}) (autorest.Response, error) {
+	contentType := "application/json; charset=utf-8"
+	if r.BodyReader != nil {
+		contentType = r.ContentType
+	}
+
+	decorators := []autorest.PrepareDecorator{
+		autorest.AsContentType(contentType),
+		autorest.WithMethod(r.HTTPMethod),
+		autorest.WithBaseURL(r.BaseURI),
+		autorest.WithPath(r.Path),
+	}
+
+	if r.Query != nil {
+		decorators = append(decorators, autorest.WithQueryParameters(r.Query))
+	}
+
+	if r.Body != nil {
+		decorators = append(decorators, autorest.WithJSON(r.Body))
+	}
+
+	req, err := autorest.CreatePreparer(decorators...).Prepare((&http.Request{}).WithContext(ctx))
+	if err != nil {
+		return autorest.Response{}, autorest.NewErrorWithError(err, r.PackageName, r.Method, nil, "Failure preparing request")
+	}
+
+	var senders []autorest.SendDecorator
+	if r.BodyReader != nil {
+		req.Body = ioutil.NopCloser(r.BodyReader)
+	} else {
+		senders = append(senders, autorest.DoRetryForStatusCodes(r.Client.RetryAttempts, r.Client.RetryDuration, autorest.StatusCodesForRetry...))
+	}
+
+	resp, err := r.Client.Send(req, senders...)
+	if err != nil {
+		return autorest.Response{Response: resp}, autorest.NewErrorWithError(err, r.PackageName, r.Method, resp, "Failure sending request")
+	}
+
+	statusCodes := r.StatusCodes
+	if len(statusCodes) == 0 {
+		statusCodes = []int{http.StatusOK}
+	}
+
+	// The response inspector decodes every body as a JSON error, so it is
+	// skipped for raw output, which is only checked by status code.
+	var responders []autorest.RespondDecorator
+	if r.Output == nil {
+		responders = append(responders, r.Client.ByInspecting())
+	}
+
+	responders = append(responders, azure.WithErrorUnlessStatusCode(statusCodes...))
+
+	if r.Output != nil {
+		responders = append(responders, byCopying(r.Output))
+	} else if result != nil {
+		responders = append(responders, autorest.ByUnmarshallingJSON(result))
+	}
+
+	responders = append(responders, autorest.ByClosing())
+
+	err = autorest.Respond(resp, responders...)
+	if err != nil {
+		return autorest.Response{Response: resp}, autorest.NewErrorWithError(err, r.PackageName, r.Method, resp, "Failure responding to request")
+	}
+
+	return autorest.Response{Response: resp}, nil
+}
+
+// byCopying copies the response body to w.
+func byCopying(w io.Writer) autorest.RespondDecorator {
+	return func(r autorest.Responder) autorest.Responder {
+		return autorest.ResponderFunc(func(resp *http.Response) error {
+			err := r.Respond(resp)
+			if err == nil {
+				_, err = io.Copy(w, resp.Body)
+			}
+			return err
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/innovationnorway/go-databricks/clusters"
+)
+
+type clusterStartAttributes struct {
+	ClusterID *string `json:"cluster_id,omitempty"`
+}
+
+// startCluster starts a terminated cluster.
+func startCluster(ctx context.Context, client clusters.BaseClient, body clusterStartAttributes) (autorest.Response, error) {
+	req := apiRequest{
+		Client:      client.Client,
+		BaseURI:     client.BaseURI,
+		PackageName: "clusters.BaseClient",
+		Method:      "Start",
+		HTTPMethod:  http.MethodPost,
+		Path:        "/clusters/start",
+		Body:        body,
+	}
+
+	return req.send(ctx, nil)
+}
+
+// waitForClusterRunning starts the cluster if it is terminated and waits
+// until it is running.
+func waitForClusterRunning(ctx context.Context, client clusters.BaseClient, clusterID string, timeout time.Duration) error {
+	resp, err := client.Get(ctx, clusterID)
+	if err != nil {
+		return fmt.Errorf("unable to get cluster: %s", err)
+	}
+
+	if resp.State == clusters.TERMINATED {
+		if _, err := startCluster(ctx, client, clusterStartAttributes{ClusterID: &clusterID}); err != nil {
+			return fmt.Errorf("unable to start cluster: %s", err)
+		}
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			string(clusters.PENDING),
+			string(clusters.RESTARTING),
+			string(clusters.RESIZING),
+		},
+		Target: []string{
+			string(clusters.RUNNING),
+		},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := client.Get(ctx, clusterID)
+			if err != nil {
+				return nil, "", err
+			}
+			return resp, string(resp.State), nil
+		},
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("unable to wait for cluster %q to start: %s", clusterID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/innovationnorway/go-databricks/clusters"
+	"github.com/innovationnorway/go-databricks/databricks"
+)
+
+// commandsAPIVersion is the path of the command execution API, which has
+// not been moved to version 2.0 of the REST API.
+const commandsAPIVersion = "/api/1.2"
+
+type commandContextAttributes struct {
+	ClusterID *string `json:"clusterId,omitempty"`
+	ContextID *string `json:"contextId,omitempty"`
+	Language  *string `json:"language,omitempty"`
+}
+
+type commandExecuteAttributes struct {
+	ClusterID *string `json:"clusterId,omitempty"`
+	ContextID *string `json:"contextId,omitempty"`
+	Language  *string `json:"language,omitempty"`
+	Command   *string `json:"command,omitempty"`
+}
+
+type commandIDResult struct {
+	autorest.Response `json:"-"`
+	ID                *string `json:"id,omitempty"`
+}
+
+type commandStatusResult struct {
+	autorest.Response `json:"-"`
+	ID                *string         `json:"id,omitempty"`
+	Status            *string         `json:"status,omitempty"`
+	Results           *commandResults `json:"results,omitempty"`
+}
+
+type commandResults struct {
+	ResultType *string `json:"resultType,omitempty"`
+	Data       *string `json:"data,omitempty"`
+	Summary    *string `json:"summary,omitempty"`
+	Cause      *string `json:"cause,omitempty"`
+}
+
+// newCommandsRequest builds a request to the command execution API. The
+// clusters client is used for its authorizer, its base URI is rewritten to
+// the 1.2 API.
+func newCommandsRequest(client clusters.BaseClient, method, httpMethod, path string) apiRequest {
+	return apiRequest{
+		Client:      client.Client,
+		BaseURI:     strings.TrimSuffix(client.BaseURI, databricks.DefaultBaseURI) + commandsAPIVersion,
+		PackageName: "commands",
+		Method:      method,
+		HTTPMethod:  httpMethod,
+		Path:        path,
+	}
+}
+
+// executePythonCommand runs a Python command on a running cluster in a new
+// execution context, and returns its text output.
+func executePythonCommand(ctx context.Context, client clusters.BaseClient, clusterID, command string, timeout time.Duration) (string, error) {
+	language := "python"
+
+	req := newCommandsRequest(client, "CreateContext", http.MethodPost, "/contexts/create")
+	req.Body = commandContextAttributes{
+		ClusterID: &clusterID,
+		Language:  &language,
+	}
+
+	var execContext commandIDResult
+	if _, err := req.send(ctx, &execContext); err != nil {
+		return "", fmt.Errorf("unable to create execution context: %s", err)
+	}
+
+	defer func() {
+		req := newCommandsRequest(client, "DestroyContext", http.MethodPost, "/contexts/destroy")
+		req.Body = commandContextAttributes{
+			ClusterID: &clusterID,
+			ContextID: execContext.ID,
+		}
+		req.send(ctx, nil)
+	}()
+
+	req = newCommandsRequest(client, "Execute", http.MethodPost, "/commands/execute")
+	req.Body = commandExecuteAttributes{
+		ClusterID: &clusterID,
+		ContextID: execContext.ID,
+		Language:  &language,
+		Command:   &command,
+	}
+
+	var execCommand commandIDResult
+	if _, err := req.send(ctx, &execCommand); err != nil {
+		return "", fmt.Errorf("unable to execute command: %s", err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"Queued", "Running"},
+		Target:  []string{"Finished"},
+		Refresh: func() (interface{}, string, error) {
+			req := newCommandsRequest(client, "Status", http.MethodGet, "/commands/status")
+			req.Query = map[string]interface{}{
+				"clusterId": autorest.Encode("query", clusterID),
+				"contextId": autorest.Encode("query", to.String(execContext.ID)),
+				"commandId": autorest.Encode("query", to.String(execCommand.ID)),
+			}
+
+			var result commandStatusResult
+			if _, err := req.send(ctx, &result); err != nil {
+				return nil, "", err
+			}
+
+			return result, to.String(result.Status), nil
+		},
+		Timeout:    timeout,
+		MinTimeout: 2 * time.Second,
+	}
+
+	raw, err := stateConf.WaitForState()
+	if err != nil {
+		return "", fmt.Errorf("unable to wait for command: %s", err)
+	}
+
+	result := raw.(commandStatusResult)
+	if result.Results == nil {
+		return "", nil
+	}
+
+	if to.String(result.Results.ResultType) == "error" {
+		return "", fmt.Errorf("command failed: %s %s", to.String(result.Results.Summary), to.String(result.Results.Cause))
+	}
+
+	return to.String(result.Results.Data), nil
+}
@@ -0,0 +1,133 @@
+package databricks
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/innovationnorway/go-databricks/dbfs"
+)
+
+type dbfsCreateAttributes struct {
+	Path      *string `json:"path,omitempty"`
+	Overwrite *bool   `json:"overwrite,omitempty"`
+}
+
+type dbfsCreateResult struct {
+	autorest.Response `json:"-"`
+	Handle            *int64 `json:"handle,omitempty"`
+}
+
+type dbfsAddBlockAttributes struct {
+	Handle *int64  `json:"handle,omitempty"`
+	Data   *string `json:"data,omitempty"`
+}
+
+type dbfsCloseAttributes struct {
+	Handle *int64 `json:"handle,omitempty"`
+}
+
+func newDbfsRequest(client dbfs.BaseClient, method, httpMethod, path string) apiRequest {
+	return apiRequest{
+		Client:      client.Client,
+		BaseURI:     client.BaseURI,
+		PackageName: "dbfs.BaseClient",
+		Method:      method,
+		HTTPMethod:  httpMethod,
+		Path:        path,
+	}
+}
+
+// dbfsCreate opens a stream to write a file and returns its handle.
+func dbfsCreate(ctx context.Context, client dbfs.BaseClient, body dbfsCreateAttributes) (result dbfsCreateResult, err error) {
+	req := newDbfsRequest(client, "Create", http.MethodPost, "/dbfs/create")
+	req.Body = body
+
+	result.Response, err = req.send(ctx, &result)
+	return
+}
+
+// dbfsAddBlock appends a block of base64 encoded data to an open stream.
+func dbfsAddBlock(ctx context.Context, client dbfs.BaseClient, body dbfsAddBlockAttributes) (autorest.Response, error) {
+	req := newDbfsRequest(client, "AddBlock", http.MethodPost, "/dbfs/add-block")
+	req.Body = body
+
+	return req.send(ctx, nil)
+}
+
+// dbfsClose closes an open stream.
+func dbfsClose(ctx context.Context, client dbfs.BaseClient, body dbfsCloseAttributes) (autorest.Response, error) {
+	req := newDbfsRequest(client, "Close", http.MethodPost, "/dbfs/close")
+	req.Body = body
+
+	return req.send(ctx, nil)
+}
+
+type dbfsFileInfo struct {
+	autorest.Response `json:"-"`
+	Path              *string `json:"path,omitempty"`
+	IsDir             *bool   `json:"is_dir,omitempty"`
+	FileSize          *int64  `json:"file_size,omitempty"`
+	ModificationTime  *int64  `json:"modification_time,omitempty"`
+}
+
+type dbfsReadResult struct {
+	autorest.Response `json:"-"`
+	BytesRead         *int64  `json:"bytes_read,omitempty"`
+	Data              *string `json:"data,omitempty"`
+}
+
+// dbfsGetStatus gets the status of a file or directory. Unlike
+// dbfs.BaseClient.GetStatus it also returns the modification time.
+func dbfsGetStatus(ctx context.Context, client dbfs.BaseClient, path string) (result dbfsFileInfo, err error) {
+	req := newDbfsRequest(client, "GetStatus", http.MethodGet, "/dbfs/get-status")
+	req.Query = map[string]interface{}{
+		"path": autorest.Encode("query", path),
+	}
+
+	result.Response, err = req.send(ctx, &result)
+	return
+}
+
+// dbfsRead reads up to length bytes of a file, starting at offset. The data
+// is returned base64 encoded.
+func dbfsRead(ctx context.Context, client dbfs.BaseClient, path string, offset, length int64) (result dbfsReadResult, err error) {
+	req := newDbfsRequest(client, "Read", http.MethodGet, "/dbfs/read")
+	req.Query = map[string]interface{}{
+		"path":   autorest.Encode("query", path),
+		"offset": autorest.Encode("query", offset),
+		"length": autorest.Encode("query", length),
+	}
+
+	result.Response, err = req.send(ctx, &result)
+	return
+}
+
+type dbfsListResult struct {
+	autorest.Response `json:"-"`
+	Files             *[]dbfsFileInfo `json:"files,omitempty"`
+}
+
+// dbfsList lists the contents of a directory, or the details of a file.
+func dbfsList(ctx context.Context, client dbfs.BaseClient, path string) (result dbfsListResult, err error) {
+	req := newDbfsRequest(client, "List", http.MethodGet, "/dbfs/list")
+	req.Query = map[string]interface{}{
+		"path": autorest.Encode("query", path),
+	}
+
+	result.Response, err = req.send(ctx, &result)
+	return
+}
+
+type dbfsMoveAttributes struct {
+	SourcePath      *string `json:"source_path,omitempty"`
+	DestinationPath *string `json:"destination_path,omitempty"`
+}
+
+// dbfsMove moves a file or directory.
+func dbfsMove(ctx context.Context, client dbfs.BaseClient, body dbfsMoveAttributes) (autorest.Response, error) {
+	req := newDbfsRequest(client, "Move", http.MethodPost, "/dbfs/move")
+	req.Body = body
+
+	return req.send(ctx, nil)
+}
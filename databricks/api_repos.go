@@ -0,0 +1,124 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/innovationnorway/go-databricks/workspace"
+)
+
+type repoSparseCheckout struct {
+	Patterns *[]string `json:"patterns,omitempty"`
+}
+
+type repoCreateAttributes struct {
+	URL            *string             `json:"url,omitempty"`
+	Provider       *string             `json:"provider,omitempty"`
+	Path           *string             `json:"path,omitempty"`
+	SparseCheckout *repoSparseCheckout `json:"sparse_checkout,omitempty"`
+}
+
+type repoUpdateAttributes struct {
+	Branch         *string             `json:"branch,omitempty"`
+	Tag            *string             `json:"tag,omitempty"`
+	SparseCheckout *repoSparseCheckout `json:"sparse_checkout,omitempty"`
+}
+
+type repoInfo struct {
+	autorest.Response `json:"-"`
+	ID                *int64              `json:"id,omitempty"`
+	URL               *string             `json:"url,omitempty"`
+	Provider          *string             `json:"provider,omitempty"`
+	Path              *string             `json:"path,omitempty"`
+	Branch            *string             `json:"branch,omitempty"`
+	Tag               *string             `json:"tag,omitempty"`
+	HeadCommitID      *string             `json:"head_commit_id,omitempty"`
+	SparseCheckout    *repoSparseCheckout `json:"sparse_checkout,omitempty"`
+}
+
+func repoPath(id string) string {
+	return fmt.Sprintf("/repos/%s", autorest.Encode("path", id))
+}
+
+// createRepo clones a Git repository into the workspace.
+func createRepo(ctx context.Context, client workspace.BaseClient, body repoCreateAttributes) (result repoInfo, err error) {
+	req := newWorkspaceRequest(client, "CreateRepo", http.MethodPost, "/repos")
+	req.Body = body
+
+	result.Response, err = req.send(ctx, &result)
+	return
+}
+
+// getRepo gets a repository, including the commit that is checked out.
+func getRepo(ctx context.Context, client workspace.BaseClient, id string) (result repoInfo, err error) {
+	req := newWorkspaceRequest(client, "GetRepo", http.MethodGet, repoPath(id))
+
+	result.Response, err = req.send(ctx, &result)
+	return
+}
+
+// updateRepo checks out a branch or tag, pulling the latest commit.
+func updateRepo(ctx context.Context, client workspace.BaseClient, id string, body repoUpdateAttributes) (autorest.Response, error) {
+	req := newWorkspaceRequest(client, "UpdateRepo", http.MethodPatch, repoPath(id))
+	req.Body = body
+
+	return req.send(ctx, nil)
+}
+
+// deleteRepo deletes a repository from the workspace.
+func deleteRepo(ctx context.Context, client workspace.BaseClient, id string) (autorest.Response, error) {
+	req := newWorkspaceRequest(client, "DeleteRepo", http.MethodDelete, repoPath(id))
+
+	return req.send(ctx, nil)
+}
+
+type gitCredentialAttributes struct {
+	GitProvider         *string `json:"git_provider,omitempty"`
+	GitUsername         *string `json:"git_username,omitempty"`
+	PersonalAccessToken *string `json:"personal_access_token,omitempty"`
+}
+
+type gitCredentialInfo struct {
+	autorest.Response `json:"-"`
+	CredentialID      *int64  `json:"credential_id,omitempty"`
+	GitProvider       *string `json:"git_provider,omitempty"`
+	GitUsername       *string `json:"git_username,omitempty"`
+}
+
+func gitCredentialPath(id string) string {
+	return fmt.Sprintf("/git-credentials/%s", autorest.Encode("path", id))
+}
+
+// createGitCredential stores the credential used to access Git providers.
+func createGitCredential(ctx context.Context, client workspace.BaseClient, body gitCredentialAttributes) (result gitCredentialInfo, err error) {
+	req := newWorkspaceRequest(client, "CreateGitCredential", http.MethodPost, "/git-credentials")
+	req.Body = body
+
+	result.Response, err = req.send(ctx, &result)
+	return
+}
+
+// getGitCredential gets a credential. The token is never returned.
+func getGitCredential(ctx context.Context, client workspace.BaseClient, id string) (result gitCredentialInfo, err error) {
+	req := newWorkspaceRequest(client, "GetGitCredential", http.MethodGet, gitCredentialPath(id))
+
+	result.Response, err = req.send(ctx, &result)
+	return
+}
+
+// updateGitCredential replaces a credential.
+func updateGitCredential(ctx context.Context, client workspace.BaseClient, id string, body gitCredentialAttributes) (autorest.Response, error) {
+	req := newWorkspaceRequest(client, "UpdateGitCredential", http.MethodPatch, gitCredentialPath(id))
+	req.Body = body
+
+	return req.send(ctx, nil)
+}
+
+// deleteGitCredential deletes a credential.
+func deleteGitCredential(ctx context.Context, client workspace.BaseClient, id string) (autorest.Response, error) {
+	req := newWorkspaceRequest(client, "DeleteGitCredential", http.MethodDelete, gitCredentialPath(id))
+
+	return req.send(ctx, nil)
+}
@@ -0,0 +1,238 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/innovationnorway/go-databricks/groups"
+)
+
+const (
+	scimUserSchema             = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimServicePrincipalSchema = "urn:ietf:params:scim:schemas:core:2.0:ServicePrincipal"
+	scimGroupSchema            = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimPatchSchema            = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+)
+
+// newScimRequest creates a request to the SCIM API, which shares its
+// authorizer with the groups client.
+func newScimRequest(client groups.BaseClient, method, httpMethod, path string) apiRequest {
+	return apiRequest{
+		Client:      client.Client,
+		BaseURI:     client.BaseURI,
+		PackageName: "scim.BaseClient",
+		Method:      method,
+		HTTPMethod:  httpMethod,
+		Path:        "/preview/scim/v2" + path,
+		StatusCodes: []int{http.StatusOK, http.StatusCreated, http.StatusNoContent},
+	}
+}
+
+func scimResourcePath(resourceType, id string) string {
+	return fmt.Sprintf("/%s/%s", resourceType, autorest.Encode("path", id))
+}
+
+type scimValue struct {
+	Value   *string `json:"value,omitempty"`
+	Display *string `json:"display,omitempty"`
+}
+
+type scimUser struct {
+	autorest.Response `json:"-"`
+	Schemas           []string     `json:"schemas,omitempty"`
+	ID                *string      `json:"id,omitempty"`
+	UserName          *string      `json:"userName,omitempty"`
+	DisplayName       *string      `json:"displayName,omitempty"`
+	Active            *bool        `json:"active,omitempty"`
+	Entitlements      *[]scimValue `json:"entitlements,omitempty"`
+	Groups            *[]scimValue `json:"groups,omitempty"`
+}
+
+type scimUserListResult struct {
+	autorest.Response `json:"-"`
+	TotalResults      *int64      `json:"totalResults,omitempty"`
+	Resources         *[]scimUser `json:"Resources,omitempty"`
+}
+
+type scimPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+type scimPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []scimPatchOperation `json:"Operations"`
+}
+
+func newScimPatchRequest(operations []scimPatchOperation) scimPatchRequest {
+	return scimPatchRequest{
+		Schemas:    []string{scimPatchSchema},
+		Operations: operations,
+	}
+}
+
+// createScimUser adds a user to the workspace.
+func createScimUser(ctx context.Context, client groups.BaseClient, body scimUser) (result scimUser, err error) {
+	req := newScimRequest(client, "CreateUser", http.MethodPost, "/Users")
+	req.Body = body
+
+	result.Response, err = req.send(ctx, &result)
+	return
+}
+
+// getScimUser gets a user by SCIM ID.
+func getScimUser(ctx context.Context, client groups.BaseClient, id string) (result scimUser, err error) {
+	req := newScimRequest(client, "GetUser", http.MethodGet, scimResourcePath("Users", id))
+
+	result.Response, err = req.send(ctx, &result)
+	return
+}
+
+// listScimUsers lists the users that match a SCIM filter expression.
+func listScimUsers(ctx context.Context, client groups.BaseClient, filter string) (result scimUserListResult, err error) {
+	req := newScimRequest(client, "ListUsers", http.MethodGet, "/Users")
+	req.Query = map[string]interface{}{
+		"filter": autorest.Encode("query", filter),
+	}
+
+	result.Response, err = req.send(ctx, &result)
+	return
+}
+
+// patchScimUser updates the given attributes of a user.
+func patchScimUser(ctx context.Context, client groups.BaseClient, id string, body scimPatchRequest) (autorest.Response, error) {
+	req := newScimRequest(client, "PatchUser", http.MethodPatch, scimResourcePath("Users", id))
+	req.Body = body
+
+	return req.send(ctx, nil)
+}
+
+// scimMe is the user or service principal the provider authenticates as.
+// Only one of UserName and ApplicationID is set.
+type scimMe struct {
+	autorest.Response `json:"-"`
+	ID                *string `json:"id,omitempty"`
+	UserName          *string `json:"userName,omitempty"`
+	ApplicationID     *string `json:"applicationId,omitempty"`
+}
+
+// getScimMe gets the user or service principal the provider authenticates as.
+func getScimMe(ctx context.Context, client groups.BaseClient) (result scimMe, err error) {
+	req := newScimRequest(client, "GetMe", http.MethodGet, "/Me")
+
+	result.Response, err = req.send(ctx, &result)
+	return
+}
+
+// deleteScimUser removes a user from the workspace.
+func deleteScimUser(ctx context.Context, client groups.BaseClient, id string) (autorest.Response, error) {
+	req := newScimRequest(client, "DeleteUser", http.MethodDelete, scimResourcePath("Users", id))
+
+	return req.send(ctx, nil)
+}
+
+type scimServicePrincipal struct {
+	autorest.Response `json:"-"`
+	Schemas           []string     `json:"schemas,omitempty"`
+	ID                *string      `json:"id,omitempty"`
+	ApplicationID     *string      `json:"applicationId,omitempty"`
+	DisplayName       *string      `json:"displayName,omitempty"`
+	Active            *bool        `json:"active,omitempty"`
+	Entitlements      *[]scimValue `json:"entitlements,omitempty"`
+	Groups            *[]scimValue `json:"groups,omitempty"`
+}
+
+// createScimServicePrincipal adds a service principal to the workspace.
+func createScimServicePrincipal(ctx context.Context, client groups.BaseClient, body scimServicePrincipal) (result scimServicePrincipal, err error) {
+	req := newScimRequest(client, "CreateServicePrincipal", http.MethodPost, "/ServicePrincipals")
+	req.Body = body
+
+	result.Response, err = req.send(ctx, &result)
+	return
+}
+
+// getScimServicePrincipal gets a service principal by SCIM ID.
+func getScimServicePrincipal(ctx context.Context, client groups.BaseClient, id string) (result scimServicePrincipal, err error) {
+	req := newScimRequest(client, "GetServicePrincipal", http.MethodGet, scimResourcePath("ServicePrincipals", id))
+
+	result.Response, err = req.send(ctx, &result)
+	return
+}
+
+// patchScimServicePrincipal updates the given attributes of a service
+// principal.
+func patchScimServicePrincipal(ctx context.Context, client groups.BaseClient, id string, body scimPatchRequest) (autorest.Response, error) {
+	req := newScimRequest(client, "PatchServicePrincipal", http.MethodPatch, scimResourcePath("ServicePrincipals", id))
+	req.Body = body
+
+	return req.send(ctx, nil)
+}
+
+// deleteScimServicePrincipal removes a service principal from the workspace.
+func deleteScimServicePrincipal(ctx context.Context, client groups.BaseClient, id string) (autorest.Response, error) {
+	req := newScimRequest(client, "DeleteServicePrincipal", http.MethodDelete, scimResourcePath("ServicePrincipals", id))
+
+	return req.send(ctx, nil)
+}
+
+type scimGroup struct {
+	autorest.Response `json:"-"`
+	Schemas           []string     `json:"schemas,omitempty"`
+	ID                *string      `json:"id,omitempty"`
+	DisplayName       *string      `json:"displayName,omitempty"`
+	Entitlements      *[]scimValue `json:"entitlements,omitempty"`
+	Roles             *[]scimValue `json:"roles,omitempty"`
+	Members           *[]scimValue `json:"members,omitempty"`
+}
+
+type scimGroupListResult struct {
+	autorest.Response `json:"-"`
+	TotalResults      *int64       `json:"totalResults,omitempty"`
+	Resources         *[]scimGroup `json:"Resources,omitempty"`
+}
+
+// createScimGroup creates a group.
+func createScimGroup(ctx context.Context, client groups.BaseClient, body scimGroup) (result scimGroup, err error) {
+	req := newScimRequest(client, "CreateGroup", http.MethodPost, "/Groups")
+	req.Body = body
+
+	result.Response, err = req.send(ctx, &result)
+	return
+}
+
+// getScimGroup gets a group by SCIM ID.
+func getScimGroup(ctx context.Context, client groups.BaseClient, id string) (result scimGroup, err error) {
+	req := newScimRequest(client, "GetGroup", http.MethodGet, scimResourcePath("Groups", id))
+
+	result.Response, err = req.send(ctx, &result)
+	return
+}
+
+// listScimGroups lists the groups that match a SCIM filter expression.
+func listScimGroups(ctx context.Context, client groups.BaseClient, filter string) (result scimGroupListResult, err error) {
+	req := newScimRequest(client, "ListGroups", http.MethodGet, "/Groups")
+	req.Query = map[string]interface{}{
+		"filter": autorest.Encode("query", filter),
+	}
+
+	result.Response, err = req.send(ctx, &result)
+	return
+}
+
+// patchScimGroup updates the given attributes of a group.
+func patchScimGroup(ctx context.Context, client groups.BaseClient, id string, body scimPatchRequest) (autorest.Response, error) {
+	req := newScimRequest(client, "PatchGroup", http.MethodPatch, scimResourcePath("Groups", id))
+	req.Body = body
+
+	return req.send(ctx, nil)
+}
+
+// deleteScimGroup deletes a group.
+func deleteScimGroup(ctx context.Context, client groups.BaseClient, id string) (autorest.Response, error) {
+	req := newScimRequest(client, "DeleteGroup", http.MethodDelete, scimResourcePath("Groups", id))
+
+	return req.send(ctx, nil)
+}
@@ -0,0 +1,38 @@
+package databricks
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/innovationnorway/go-databricks/secrets"
+)
+
+// secretAclsListResult is the response of /secrets/acls/list. The API returns
+// the ACLs under "items", which secrets.ListSecretAclsResult does not decode.
+type secretAclsListResult struct {
+	autorest.Response `json:"-"`
+	Items             *[]secrets.ACLItemAttributes `json:"items,omitempty"`
+}
+
+func newSecretsRequest(client secrets.BaseClient, method, httpMethod, path string) apiRequest {
+	return apiRequest{
+		Client:      client.Client,
+		BaseURI:     client.BaseURI,
+		PackageName: "secrets.BaseClient",
+		Method:      method,
+		HTTPMethod:  httpMethod,
+		Path:        path,
+	}
+}
+
+// listSecretAcls lists the ACLs of a scope.
+func listSecretAcls(ctx context.Context, client secrets.BaseClient, scope string) (result secretAclsListResult, err error) {
+	req := newSecretsRequest(client, "ListAcls", http.MethodGet, "/secrets/acls/list")
+	req.Query = map[string]interface{}{
+		"scope": autorest.Encode("query", scope),
+	}
+
+	result.Response, err = req.send(ctx, &result)
+	return
+}
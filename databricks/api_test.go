@@ -0,0 +1,120 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// testAPIRequest is a request received by testAPI. Body is the decoded JSON
+// body, if any.
+type testAPIRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   map[string]interface{}
+}
+
+// testAPIHandler returns the status code and the JSON encoded response of a
+// request.
+type testAPIHandler func(req testAPIRequest) (int, interface{})
+
+// testAPI is a fake of the Databricks REST API. Handlers are keyed by method
+// and path below /api/2.0, e.g. "POST /dbfs/put". Requests without a handler
+// get a 404 RESOURCE_DOES_NOT_EXIST error.
+type testAPI struct {
+	mu       sync.Mutex
+	handlers map[string]testAPIHandler
+	requests []testAPIRequest
+}
+
+func newTestAPI(handlers map[string]testAPIHandler) *testAPI {
+	return &testAPI{handlers: handlers}
+}
+
+func (a *testAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req := testAPIRequest{
+		Method: r.Method,
+		Path:   strings.TrimPrefix(r.URL.Path, "/api/2.0"),
+		Query:  r.URL.Query(),
+	}
+
+	if content, _ := ioutil.ReadAll(r.Body); len(content) > 0 {
+		json.Unmarshal(content, &req.Body)
+	}
+
+	a.mu.Lock()
+	a.requests = append(a.requests, req)
+	handler, ok := a.handlers[req.Method+" "+req.Path]
+	a.mu.Unlock()
+
+	status, body := http.StatusNotFound, interface{}(map[string]string{
+		"error_code": "RESOURCE_DOES_NOT_EXIST",
+		"message":    req.Path + " does not exist",
+	})
+	if ok {
+		status, body = handler(req)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if body != nil {
+		json.NewEncoder(w).Encode(body)
+	}
+}
+
+// calls returns the method and path of every request received, in order.
+func (a *testAPI) calls() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	result := make([]string, 0, len(a.requests))
+	for _, req := range a.requests {
+		result = append(result, req.Method+" "+req.Path)
+	}
+
+	return result
+}
+
+// find returns the requests received with the given method and path.
+func (a *testAPI) find(call string) []testAPIRequest {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var result []testAPIRequest
+	for _, req := range a.requests {
+		if req.Method+" "+req.Path == call {
+			result = append(result, req)
+		}
+	}
+
+	return result
+}
+
+// testAPIMeta returns the clients of a provider configured against api.
+func testAPIMeta(t *testing.T, api *testAPI) *Meta {
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	meta, err := (&Config{}).createClients(server.URL+"/api/2.0", autorest.NullAuthorizer{})
+	if err != nil {
+		t.Fatalf("unable to create clients: %s", err)
+	}
+
+	for _, client := range []*autorest.Client{&meta.Clusters.Client, &meta.Dbfs.Client, &meta.Groups.Client, &meta.Workspace.Client, &meta.Secrets.Client} {
+		client.RetryAttempts = 1
+		client.RetryDuration = 0
+	}
+
+	meta.StopContext = context.Background()
+
+	return meta
+}
@@ -0,0 +1,86 @@
+package databricks
+
+import (
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/innovationnorway/go-databricks/workspace"
+)
+
+// workspaceAuto imports and exports workspace files as they are, rather
+// than as notebooks.
+const workspaceAuto workspace.Format = "AUTO"
+
+func newWorkspaceRequest(client workspace.BaseClient, method, httpMethod, path string) apiRequest {
+	return apiRequest{
+		Client:      client.Client,
+		BaseURI:     client.BaseURI,
+		PackageName: "workspace.BaseClient",
+		Method:      method,
+		HTTPMethod:  httpMethod,
+		Path:        path,
+	}
+}
+
+// importWorkspaceFileStream imports the content of r as a multipart form,
+// which is streamed and so is not subject to the size limit of the content
+// of a JSON request.
+func importWorkspaceFileStream(ctx context.Context, client workspace.BaseClient, path string, format workspace.Format, overwrite bool, r io.Reader) (autorest.Response, error) {
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	form := multipart.NewWriter(pw)
+
+	go func() {
+		pw.CloseWithError(writeWorkspaceImportForm(form, path, format, overwrite, r))
+	}()
+
+	req := newWorkspaceRequest(client, "Import", http.MethodPost, "/workspace/import")
+	req.BodyReader = pr
+	req.ContentType = form.FormDataContentType()
+
+	return req.send(ctx, nil)
+}
+
+func writeWorkspaceImportForm(form *multipart.Writer, path string, format workspace.Format, overwrite bool, r io.Reader) error {
+	fields := map[string]string{
+		"path":      path,
+		"format":    string(format),
+		"overwrite": strconv.FormatBool(overwrite),
+	}
+
+	for name, value := range fields {
+		if err := form.WriteField(name, value); err != nil {
+			return err
+		}
+	}
+
+	part, err := form.CreateFormFile("content", "content")
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(part, r); err != nil {
+		return err
+	}
+
+	return form.Close()
+}
+
+// exportWorkspaceFile writes the content of an object to w, downloading it
+// directly instead of base64 encoded in a JSON response.
+func exportWorkspaceFile(ctx context.Context, client workspace.BaseClient, path string, format workspace.Format, w io.Writer) (autorest.Response, error) {
+	req := newWorkspaceRequest(client, "Export", http.MethodGet, "/workspace/export")
+	req.Query = map[string]interface{}{
+		"path":            autorest.Encode("query", path),
+		"format":          autorest.Encode("query", format),
+		"direct_download": autorest.Encode("query", true),
+	}
+	req.Output = w
+
+	return req.send(ctx, nil)
+}
@@ -0,0 +1,75 @@
+package databricks
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceDatabricksDbfsFile() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDatabricksDbfsFileRead,
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"content": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"content_base64": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"file_size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"modification_time": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceDatabricksDbfsFileRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Dbfs
+	ctx := meta.(*Meta).StopContext
+
+	path := d.Get("path").(string)
+
+	resp, err := dbfsGetStatus(ctx, client, path)
+	if err != nil {
+		return fmt.Errorf("unable to get file status: %s", err)
+	}
+
+	if to.Bool(resp.IsDir) {
+		return fmt.Errorf("unable to read %q, it is a directory", path)
+	}
+
+	var buf bytes.Buffer
+	if err := readDbfsFile(ctx, client, path, &buf); err != nil {
+		return err
+	}
+
+	d.Set("content", buf.String())
+	d.Set("content_base64", base64.StdEncoding.EncodeToString(buf.Bytes()))
+	d.Set("file_size", resp.FileSize)
+	d.Set("modification_time", resp.ModificationTime)
+
+	d.SetId(path)
+
+	return nil
+}
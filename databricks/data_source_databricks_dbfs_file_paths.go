@@ -0,0 +1,121 @@
+package databricks
+
+import (
+	"fmt"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceDatabricksDbfsFilePaths() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDatabricksDbfsFilePathsRead,
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"recursive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"path_list": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_dir": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"file_size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"modification_time": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDatabricksDbfsFilePathsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Dbfs
+	ctx := meta.(*Meta).StopContext
+
+	path := d.Get("path").(string)
+	recursive := d.Get("recursive").(bool)
+
+	var result []dbfsFileInfo
+
+	pending := []string{path}
+	for len(pending) > 0 {
+		dir := pending[0]
+		pending = pending[1:]
+
+		resp, err := dbfsList(ctx, client, dir)
+		if err != nil {
+			return fmt.Errorf("unable to list %q: %s", dir, err)
+		}
+
+		if resp.Files == nil {
+			continue
+		}
+
+		for _, item := range *resp.Files {
+			result = append(result, item)
+
+			if recursive && to.Bool(item.IsDir) {
+				pending = append(pending, to.String(item.Path))
+			}
+		}
+	}
+
+	d.Set("path_list", flattenDbfsFileInfos(result))
+
+	d.SetId(path)
+
+	return nil
+}
+
+func flattenDbfsFileInfos(input []dbfsFileInfo) []interface{} {
+	result := make([]interface{}, 0, len(input))
+
+	for _, item := range input {
+		values := make(map[string]interface{})
+
+		if item.Path != nil {
+			values["path"] = *item.Path
+		}
+
+		if item.IsDir != nil {
+			values["is_dir"] = *item.IsDir
+		}
+
+		if item.FileSize != nil {
+			values["file_size"] = int(*item.FileSize)
+		}
+
+		if item.ModificationTime != nil {
+			values["modification_time"] = int(*item.ModificationTime)
+		}
+
+		result = append(result, values)
+	}
+
+	return result
+}
@@ -0,0 +1,52 @@
+package databricks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceDatabricksDbfsFile_basic(t *testing.T) {
+	resourceName := "data.databricks_dbfs_file.test"
+	pathsName := "data.databricks_dbfs_file_paths.test"
+	dir := fmt.Sprintf("/tmp/%s", acctest.RandString(6))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDatabricksDbfsFileBasic(dir),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "content", "Hello, world!"),
+					resource.TestCheckResourceAttr(resourceName, "content_base64", "SGVsbG8sIHdvcmxkIQ=="),
+					resource.TestCheckResourceAttr(resourceName, "file_size", "13"),
+					resource.TestCheckResourceAttr(pathsName, "path_list.#", "1"),
+					resource.TestCheckResourceAttr(pathsName, "path_list.0.path", dir+"/hello.txt"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceDatabricksDbfsFileBasic(dir string) string {
+	return fmt.Sprintf(`
+resource "databricks_dbfs_upload" "test" {
+  path     = "%s/hello.txt"
+  contents = base64encode("Hello, world!")
+}
+
+data "databricks_dbfs_file" "test" {
+  path = databricks_dbfs_upload.test.path
+}
+
+data "databricks_dbfs_file_paths" "test" {
+  path      = "%s"
+  recursive = true
+
+  depends_on = [databricks_dbfs_upload.test]
+}
+`, dir, dir)
+}
@@ -0,0 +1,80 @@
+package databricks
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/innovationnorway/go-databricks/workspace"
+)
+
+func dataSourceDatabricksNotebook() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDatabricksNotebookRead,
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"format": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  string(workspace.SOURCE),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(workspace.SOURCE),
+					string(workspace.HTML),
+					string(workspace.JUPYTER),
+					string(workspace.DBC),
+				}, false),
+			},
+
+			"content": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"language": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"object_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceDatabricksNotebookRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	path := d.Get("path").(string)
+
+	status, err := client.GetStatus(ctx, path)
+	if err != nil {
+		return fmt.Errorf("unable to get object status: %s", err)
+	}
+
+	resp, err := client.Export(ctx, path, d.Get("format").(string), nil)
+	if err != nil {
+		return fmt.Errorf("unable to export object: %s", err)
+	}
+
+	d.Set("content", resp.Content)
+	d.Set("language", status.Language)
+
+	if err := d.Set("object_id", strconv.FormatInt(to.Int64(status.ObjectID), 10)); err != nil {
+		return err
+	}
+
+	d.SetId(path)
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package databricks
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestAccDataSourceDatabricksNotebook_basic(t *testing.T) {
+	resourceName := "data.databricks_notebook.test"
+	objectsName := "data.databricks_workspace_objects.test"
+	dir := fmt.Sprintf("/Shared/%s", acctest.RandString(6))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDatabricksNotebookBasic(dir),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "language", "PYTHON"),
+					resource.TestCheckResourceAttrPair(resourceName, "object_id", "databricks_workspace_import.test", "object_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "content"),
+					resource.TestCheckResourceAttr(objectsName, "objects.#", "1"),
+					resource.TestCheckResourceAttr(objectsName, "objects.0.path", dir+"/nested/hello"),
+					resource.TestCheckResourceAttr(objectsName, "objects.0.object_type", "NOTEBOOK"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceDatabricksNotebookRead(t *testing.T) {
+	api := newTestAPI(map[string]testAPIHandler{
+		"GET /workspace/get-status": func(req testAPIRequest) (int, interface{}) {
+			return http.StatusOK, map[string]interface{}{
+				"path":        req.Query.Get("path"),
+				"object_type": "NOTEBOOK",
+				"language":    "PYTHON",
+				"object_id":   1234567890123,
+			}
+		},
+		"GET /workspace/export": func(req testAPIRequest) (int, interface{}) {
+			return http.StatusOK, map[string]interface{}{
+				"content": base64.StdEncoding.EncodeToString([]byte("print(1)\n")),
+			}
+		},
+	})
+	meta := testAPIMeta(t, api)
+
+	d := schema.TestResourceDataRaw(t, dataSourceDatabricksNotebook().Schema, map[string]interface{}{
+		"path": "/Shared/example",
+	})
+
+	if err := dataSourceDatabricksNotebookRead(d, meta); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if actual := d.Get("object_id").(string); actual != "1234567890123" {
+		t.Errorf("expected object_id %q, got %q", "1234567890123", actual)
+	}
+
+	if actual := d.Get("language").(string); actual != "PYTHON" {
+		t.Errorf("expected language %q, got %q", "PYTHON", actual)
+	}
+}
+
+func testAccDataSourceDatabricksNotebookBasic(dir string) string {
+	return fmt.Sprintf(`
+resource "databricks_directory" "test" {
+  path             = "%s/nested"
+  delete_recursive = true
+}
+
+resource "databricks_workspace_import" "test" {
+  path     = "${databricks_directory.test.path}/hello"
+  content  = base64encode("print(\"Hello, world!\")")
+  language = "PYTHON"
+}
+
+data "databricks_notebook" "test" {
+  path = databricks_workspace_import.test.path
+}
+
+data "databricks_workspace_objects" "test" {
+  path        = "%s"
+  recursive   = true
+  object_type = "NOTEBOOK"
+
+  depends_on = [databricks_workspace_import.test]
+}
+`, dir, dir)
+}
@@ -0,0 +1,107 @@
+package databricks
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/innovationnorway/go-databricks/secrets"
+)
+
+func dataSourceDatabricksSecretKeys() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDatabricksSecretKeysRead,
+
+		Schema: map[string]*schema.Schema{
+			"scope": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"secrets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_updated_timestamp": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDatabricksSecretKeysRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Secrets
+	ctx := meta.(*Meta).StopContext
+
+	scope := d.Get("scope").(string)
+
+	resp, err := client.List(ctx, secrets.ListSecretsAttributes{Scope: &scope})
+	if err != nil {
+		return fmt.Errorf("unable to list secrets: %s", err)
+	}
+
+	d.Set("secrets", flattenSecretMetadata(resp.SecretsProperty))
+	d.Set("keys", flattenSecretKeys(resp.SecretsProperty))
+
+	d.SetId(scope)
+
+	return nil
+}
+
+func flattenSecretMetadata(input *[]secrets.MetadataAttributes) []interface{} {
+	result := make([]interface{}, 0)
+
+	if input == nil {
+		return result
+	}
+
+	for _, item := range *input {
+		values := make(map[string]interface{})
+
+		if item.Key != nil {
+			values["key"] = *item.Key
+		}
+
+		if item.LastUpdatedTimestamp != nil {
+			values["last_updated_timestamp"] = int(*item.LastUpdatedTimestamp)
+		}
+
+		result = append(result, values)
+	}
+
+	return result
+}
+
+func flattenSecretKeys(input *[]secrets.MetadataAttributes) []interface{} {
+	result := make([]interface{}, 0)
+
+	if input == nil {
+		return result
+	}
+
+	for _, item := range *input {
+		if item.Key != nil {
+			result = append(result, *item.Key)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,53 @@
+package databricks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceDatabricksSecretKeys_basic(t *testing.T) {
+	resourceName := "data.databricks_secret_keys.test"
+	scope := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDatabricksSecretKeysBasic(scope),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "scope", scope),
+					resource.TestCheckResourceAttr(resourceName, "keys.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "keys.0", "foo"),
+					resource.TestCheckResourceAttrSet(resourceName, "secrets.0.last_updated_timestamp"),
+					resource.TestCheckResourceAttrSet("data.databricks_secret_scopes.test", "names.0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceDatabricksSecretKeysBasic(scope string) string {
+	return fmt.Sprintf(`
+resource "databricks_secret_scope" "test" {
+  scope = "%s"
+}
+
+resource "databricks_secret" "test" {
+  scope        = databricks_secret_scope.test.scope
+  key          = "foo"
+  string_value = "bar"
+}
+
+data "databricks_secret_scopes" "test" {
+  depends_on = [databricks_secret_scope.test]
+}
+
+data "databricks_secret_keys" "test" {
+  scope = databricks_secret.test.scope
+}
+`, scope)
+}
@@ -0,0 +1,97 @@
+package databricks
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/innovationnorway/go-databricks/secrets"
+)
+
+func dataSourceDatabricksSecretScopes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDatabricksSecretScopesRead,
+
+		Schema: map[string]*schema.Schema{
+			"scopes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"backend_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDatabricksSecretScopesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Secrets
+	ctx := meta.(*Meta).StopContext
+
+	resp, err := client.ListScopes(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list secret scopes: %s", err)
+	}
+
+	d.Set("scopes", flattenSecretScopes(resp.Scopes))
+	d.Set("names", flattenSecretScopeNames(resp.Scopes))
+
+	// There is one set of scopes per workspace.
+	d.SetId(client.BaseURI)
+
+	return nil
+}
+
+func flattenSecretScopes(input *[]secrets.ScopeAttributes) []interface{} {
+	result := make([]interface{}, 0)
+
+	if input == nil {
+		return result
+	}
+
+	for _, item := range *input {
+		values := make(map[string]interface{})
+
+		if item.Name != nil {
+			values["name"] = *item.Name
+		}
+
+		values["backend_type"] = string(item.BackendType)
+
+		result = append(result, values)
+	}
+
+	return result
+}
+
+func flattenSecretScopeNames(input *[]secrets.ScopeAttributes) []interface{} {
+	result := make([]interface{}, 0)
+
+	if input == nil {
+		return result
+	}
+
+	for _, item := range *input {
+		if item.Name != nil {
+			result = append(result, *item.Name)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,87 @@
+package databricks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceDatabricksUser() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDatabricksUserRead,
+
+		Schema: map[string]*schema.Schema{
+			"user_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"display_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"active": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"home": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceDatabricksUserRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Groups
+	ctx := meta.(*Meta).StopContext
+
+	userName := d.Get("user_name").(string)
+
+	resp, err := listScimUsers(ctx, client, scimEqualFilter("userName", userName))
+	if err != nil {
+		return fmt.Errorf("unable to list users: %s", err)
+	}
+
+	if resp.Resources == nil || len(*resp.Resources) == 0 {
+		return fmt.Errorf("unable to find user %q", userName)
+	}
+
+	user := (*resp.Resources)[0]
+
+	var groups []string
+	if user.Groups != nil {
+		for _, item := range *user.Groups {
+			groups = append(groups, to.String(item.Display))
+		}
+	}
+
+	d.Set("display_name", user.DisplayName)
+	d.Set("active", to.Bool(user.Active))
+	d.Set("groups", groups)
+	d.Set("home", fmt.Sprintf("/Users/%s", to.String(user.UserName)))
+
+	d.SetId(to.String(user.ID))
+
+	return nil
+}
+
+// scimEqualFilter returns a SCIM filter expression matching resources whose
+// attribute equals value.
+func scimEqualFilter(attribute, value string) string {
+	return fmt.Sprintf(`%s eq "%s"`, attribute, strings.Replace(value, `"`, `\"`, -1))
+}
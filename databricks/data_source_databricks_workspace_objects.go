@@ -0,0 +1,163 @@
+package databricks
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/innovationnorway/go-databricks/workspace"
+)
+
+func dataSourceDatabricksWorkspaceObjects() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDatabricksWorkspaceObjectsRead,
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"recursive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"object_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(workspace.NOTEBOOK),
+					string(workspace.DIRECTORY),
+					string(workspace.LIBRARY),
+				}, false),
+			},
+
+			"language": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(workspace.SCALA),
+					string(workspace.PYTHON),
+					string(workspace.SQL),
+					string(workspace.R),
+				}, false),
+			},
+
+			"object_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"objects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"object_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"language": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"object_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDatabricksWorkspaceObjectsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	path := d.Get("path").(string)
+	recursive := d.Get("recursive").(bool)
+	objectType := d.Get("object_type").(string)
+	language := d.Get("language").(string)
+	objectID := d.Get("object_id").(string)
+
+	var result []workspace.ObjectInfo
+
+	pending := []string{path}
+	for len(pending) > 0 {
+		dir := pending[0]
+		pending = pending[1:]
+
+		resp, err := client.List(ctx, dir)
+		if err != nil {
+			return fmt.Errorf("unable to list %q: %s", dir, err)
+		}
+
+		if resp.Objects == nil {
+			continue
+		}
+
+		for _, item := range *resp.Objects {
+			if recursive && item.ObjectType == workspace.DIRECTORY {
+				pending = append(pending, to.String(item.Path))
+			}
+
+			if objectType != "" && string(item.ObjectType) != objectType {
+				continue
+			}
+
+			if language != "" && to.String(item.Language) != language {
+				continue
+			}
+
+			if objectID != "" && (item.ObjectID == nil || strconv.FormatInt(*item.ObjectID, 10) != objectID) {
+				continue
+			}
+
+			result = append(result, item)
+		}
+	}
+
+	d.Set("objects", flattenWorkspaceObjectInfos(result))
+
+	d.SetId(path)
+
+	return nil
+}
+
+func flattenWorkspaceObjectInfos(input []workspace.ObjectInfo) []interface{} {
+	result := make([]interface{}, 0, len(input))
+
+	for _, item := range input {
+		values := map[string]interface{}{
+			"object_type": string(item.ObjectType),
+		}
+
+		if item.Path != nil {
+			values["path"] = *item.Path
+		}
+
+		if item.Language != nil {
+			values["language"] = *item.Language
+		}
+
+		if item.ObjectID != nil {
+			values["object_id"] = strconv.FormatInt(*item.ObjectID, 10)
+		}
+
+		result = append(result, values)
+	}
+
+	return result
+}
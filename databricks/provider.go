@@ -80,20 +80,41 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"databricks_cluster":       dataSourceDatabricksCluster(),
-			"databricks_group_members": dataSourceDatabricksGroupMembers(),
+			"databricks_cluster":           dataSourceDatabricksCluster(),
+			"databricks_dbfs_file":         dataSourceDatabricksDbfsFile(),
+			"databricks_dbfs_file_paths":   dataSourceDatabricksDbfsFilePaths(),
+			"databricks_group_members":     dataSourceDatabricksGroupMembers(),
+			"databricks_notebook":          dataSourceDatabricksNotebook(),
+			"databricks_secret_keys":       dataSourceDatabricksSecretKeys(),
+			"databricks_secret_scopes":     dataSourceDatabricksSecretScopes(),
+			"databricks_user":              dataSourceDatabricksUser(),
+			"databricks_workspace_objects": dataSourceDatabricksWorkspaceObjects(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"databricks_cluster":          resourceDatabricksCluster(),
-			"databricks_dbfs_mkdirs":      resourceDatabricksDbfsMkdirs(),
-			"databricks_dbfs_upload":      resourceDatabricksDbfsUpload(),
-			"databricks_group":            resourceDatabricksGroup(),
-			"databricks_group_member":     resourceDatabricksGroupMember(),
-			"databricks_workspace_import": resourceDatabricksWorkspaceImport(),
-			"databricks_secret":           resourceDatabricksSecret(),
-			"databricks_secret_scope":     resourceDatabricksSecretScope(),
-			"databricks_secret_acl":       resourceDatabricksSecretAcl(),
+			"databricks_cluster":           resourceDatabricksCluster(),
+			"databricks_dbfs_file":         resourceDatabricksDbfsFile(),
+			"databricks_dbfs_mkdirs":       resourceDatabricksDbfsMkdirs(),
+			"databricks_dbfs_sync":         resourceDatabricksDbfsSync(),
+			"databricks_dbfs_upload":       resourceDatabricksDbfsUpload(),
+			"databricks_directory":         resourceDatabricksDirectory(),
+			"databricks_git_credential":    resourceDatabricksGitCredential(),
+			"databricks_group":             resourceDatabricksGroup(),
+			"databricks_group_member":      resourceDatabricksGroupMember(),
+			"databricks_group_members":     resourceDatabricksGroupMembers(),
+			"databricks_mount":             resourceDatabricksMount(),
+			"databricks_notebook":          resourceDatabricksNotebook(),
+			"databricks_notebook_sync":     resourceDatabricksNotebookSync(),
+			"databricks_repo":              resourceDatabricksRepo(),
+			"databricks_service_principal": resourceDatabricksServicePrincipal(),
+			"databricks_user":              resourceDatabricksUser(),
+			"databricks_workspace_file":    resourceDatabricksWorkspaceFile(),
+			"databricks_workspace_import":  resourceDatabricksWorkspaceImport(),
+			"databricks_secret":            resourceDatabricksSecret(),
+			"databricks_secret_scope":      resourceDatabricksSecretScope(),
+			"databricks_secret_acl":        resourceDatabricksSecretAcl(),
+			"databricks_secret_scope_acls": resourceDatabricksSecretScopeAcls(),
+			"databricks_secrets":           resourceDatabricksSecrets(),
 		},
 	}
 
@@ -1,7 +1,11 @@
 package databricks
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/to"
@@ -9,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/innovationnorway/go-databricks/clusters"
 	"github.com/innovationnorway/go-databricks/databricks"
+	"github.com/innovationnorway/go-databricks/secrets"
 )
 
 func resourceDatabricksCluster() *schema.Resource {
@@ -18,6 +23,8 @@ func resourceDatabricksCluster() *schema.Resource {
 		Update: resourceDatabricksClusterUpdate,
 		Delete: resourceDatabricksClusterDelete,
 
+		CustomizeDiff: resourceDatabricksClusterCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"num_workers": {
 				Type:         schema.TypeInt,
@@ -592,6 +599,123 @@ func resourceDatabricksClusterDelete(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
+// resourceDatabricksClusterCustomizeDiff checks that every secret referenced
+// from spark_conf or spark_env_vars exists, so a typo in a scope or key fails
+// the plan rather than the cluster start. Values that are not known yet, e.g.
+// because they interpolate a secret created in the same apply, are skipped.
+func resourceDatabricksClusterCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChange("spark_conf") && !d.HasChange("spark_env_vars") {
+		return nil
+	}
+
+	var references []clusterSecretReference
+	for _, k := range []string{"spark_conf", "spark_env_vars"} {
+		if !d.NewValueKnown(k) {
+			continue
+		}
+
+		known := make(map[string]interface{})
+		for name, value := range d.Get(k).(map[string]interface{}) {
+			if d.NewValueKnown(fmt.Sprintf("%s.%s", k, name)) {
+				known[name] = value
+			}
+		}
+
+		references = append(references, findClusterSecretReferences(k, known)...)
+	}
+
+	if len(references) == 0 {
+		return nil
+	}
+
+	client := meta.(*Meta).Secrets
+	ctx := meta.(*Meta).StopContext
+
+	resp, err := client.ListScopes(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list secret scopes: %s", err)
+	}
+
+	keys := make(map[string]*[]secrets.MetadataAttributes)
+	var missingScopes, missingKeys []string
+
+	for _, ref := range references {
+		if !isExistingScope(ref.Scope, resp.Scopes) {
+			missingScopes = append(missingScopes, ref.String())
+			continue
+		}
+
+		if _, ok := keys[ref.Scope]; !ok {
+			scope := ref.Scope
+			list, err := client.List(ctx, secrets.ListSecretsAttributes{Scope: &scope})
+			if err != nil {
+				return fmt.Errorf("unable to list secrets in scope %q: %s", ref.Scope, err)
+			}
+			keys[ref.Scope] = list.SecretsProperty
+		}
+
+		if !isExistingSecret(ref.Key, keys[ref.Scope]) {
+			missingKeys = append(missingKeys, ref.String())
+		}
+	}
+
+	var errs []string
+	if len(missingScopes) > 0 {
+		errs = append(errs, fmt.Sprintf("secret scopes do not exist: %s", strings.Join(missingScopes, ", ")))
+	}
+
+	if len(missingKeys) > 0 {
+		errs = append(errs, fmt.Sprintf("secrets do not exist: %s", strings.Join(missingKeys, ", ")))
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+var clusterSecretReferenceRegexp = regexp.MustCompile(`\{\{\s*secrets/([^/\s}]+)/([^/\s}]+)\s*\}\}`)
+
+type clusterSecretReference struct {
+	Attribute string
+	Name      string
+	Scope     string
+	Key       string
+}
+
+func (r clusterSecretReference) String() string {
+	return fmt.Sprintf("%s.%s ({{secrets/%s/%s}})", r.Attribute, r.Name, r.Scope, r.Key)
+}
+
+func findClusterSecretReferences(attribute string, input map[string]interface{}) []clusterSecretReference {
+	names := make([]string, 0, len(input))
+	for name := range input {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []clusterSecretReference
+
+	for _, name := range names {
+		value, ok := input[name].(string)
+		if !ok {
+			continue
+		}
+
+		for _, match := range clusterSecretReferenceRegexp.FindAllStringSubmatch(value, -1) {
+			result = append(result, clusterSecretReference{
+				Attribute: attribute,
+				Name:      name,
+				Scope:     match[1],
+				Key:       match[2],
+			})
+		}
+	}
+
+	return result
+}
+
 func expandClusterAutoscale(input []interface{}) *clusters.AutoScale {
 	if len(input) == 0 {
 		return nil
@@ -2,6 +2,9 @@ package databricks
 
 import (
 	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
@@ -9,6 +12,96 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
 
+func TestFindClusterSecretReferences(t *testing.T) {
+	input := map[string]interface{}{
+		"spark.hadoop.fs.azure.account.key": "{{secrets/storage-scope/account-key}}",
+		"spark.plain":                       "value",
+		"spark.jdbc":                        "user={{ secrets/jdbc/user }};password={{secrets/jdbc/password}}",
+	}
+
+	expected := []clusterSecretReference{
+		{Attribute: "spark_conf", Name: "spark.hadoop.fs.azure.account.key", Scope: "storage-scope", Key: "account-key"},
+		{Attribute: "spark_conf", Name: "spark.jdbc", Scope: "jdbc", Key: "user"},
+		{Attribute: "spark_conf", Name: "spark.jdbc", Scope: "jdbc", Key: "password"},
+	}
+
+	actual := findClusterSecretReferences("spark_conf", input)
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected:\n%#v\ngot:\n%#v", expected, actual)
+	}
+}
+
+func TestResourceDatabricksClusterCustomizeDiff(t *testing.T) {
+	// unknown is the value Terraform uses for values not known at plan time.
+	const unknown = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+	cases := []struct {
+		name       string
+		sparkConf  map[string]interface{}
+		expectErr  string
+		expectCall bool
+	}{
+		{
+			name:       "existing",
+			sparkConf:  map[string]interface{}{"spark.key": "{{secrets/example/present}}"},
+			expectCall: true,
+		},
+		{
+			name:       "missing key",
+			sparkConf:  map[string]interface{}{"spark.key": "{{secrets/example/mistyped}}"},
+			expectErr:  "secrets do not exist: spark_conf.spark.key ({{secrets/example/mistyped}})",
+			expectCall: true,
+		},
+		{
+			name:       "missing scope",
+			sparkConf:  map[string]interface{}{"spark.key": "{{secrets/other/present}}"},
+			expectErr:  "secret scopes do not exist: spark_conf.spark.key ({{secrets/other/present}})",
+			expectCall: true,
+		},
+		{
+			name:      "unknown",
+			sparkConf: map[string]interface{}{"spark.key": unknown},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			api := newTestAPI(map[string]testAPIHandler{
+				"GET /secrets/scopes/list": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{"scopes": []map[string]interface{}{{"name": "example"}}}
+				},
+				"GET /secrets/list": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{"secrets": []map[string]interface{}{{"key": "present"}}}
+				},
+			})
+			meta := testAPIMeta(t, api)
+
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"cluster_name":  "example",
+				"spark_version": "6.4.x-scala2.11",
+				"node_type_id":  "Standard_DS3_v2",
+				"num_workers":   1,
+				"spark_conf":    c.sparkConf,
+			})
+
+			_, err := resourceDatabricksCluster().Diff(nil, config, meta)
+
+			if c.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.expectErr) {
+					t.Fatalf("expected error containing %q, got %v", c.expectErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if called := len(api.calls()) > 0; called != c.expectCall {
+				t.Errorf("expected secrets to be listed: %t, got calls %v", c.expectCall, api.calls())
+			}
+		})
+	}
+}
+
 func TestAccDatabricksCluster_AutoScale(t *testing.T) {
 	resourceName := "databricks_cluster.test"
 	clusterName := acctest.RandString(6)
@@ -0,0 +1,230 @@
+package databricks
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/innovationnorway/go-databricks/dbfs"
+)
+
+func resourceDatabricksDbfsFile() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDatabricksDbfsFileCreate,
+		Read:   resourceDatabricksDbfsFileRead,
+		Update: resourceDatabricksDbfsFileUpdate,
+		Delete: resourceDatabricksDbfsFileDelete,
+
+		CustomizeDiff: resourceDatabricksDbfsFileCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"source": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"md5": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"file_size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"modification_time": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDatabricksDbfsFileCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Dbfs
+	ctx := meta.(*Meta).StopContext
+
+	path := d.Get("path").(string)
+
+	if err := uploadDbfsFileFromSource(ctx, client, path, d.Get("source").(string), false); err != nil {
+		return err
+	}
+
+	d.SetId(path)
+
+	return resourceDatabricksDbfsFileRead(d, meta)
+}
+
+func resourceDatabricksDbfsFileRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Dbfs
+	ctx := meta.(*Meta).StopContext
+
+	resp, err := dbfsGetStatus(ctx, client, d.Id())
+	if err != nil {
+		if resp.IsHTTPStatus(404) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("unable to get file status: %s", err)
+	}
+
+	md5Hash := md5.New()
+	sha256Hash := sha256.New()
+
+	if err := readDbfsFile(ctx, client, d.Id(), io.MultiWriter(md5Hash, sha256Hash)); err != nil {
+		return err
+	}
+
+	d.Set("path", resp.Path)
+	d.Set("md5", hex.EncodeToString(md5Hash.Sum(nil)))
+	d.Set("sha256", hex.EncodeToString(sha256Hash.Sum(nil)))
+	d.Set("file_size", resp.FileSize)
+	d.Set("modification_time", resp.ModificationTime)
+
+	return nil
+}
+
+func resourceDatabricksDbfsFileUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Dbfs
+	ctx := meta.(*Meta).StopContext
+
+	if err := uploadDbfsFileFromSource(ctx, client, d.Id(), d.Get("source").(string), true); err != nil {
+		return err
+	}
+
+	return resourceDatabricksDbfsFileRead(d, meta)
+}
+
+func resourceDatabricksDbfsFileDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Dbfs
+	ctx := meta.(*Meta).StopContext
+
+	attributes := dbfs.DeleteAttributes{
+		Path: to.StringPtr(d.Id()),
+	}
+
+	resp, err := client.Delete(ctx, attributes)
+	if err != nil {
+		if resp.IsHTTPStatus(404) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("unable to delete file: %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// resourceDatabricksDbfsFileCustomizeDiff hashes the local source file. When
+// it no longer matches the hashes of the remote file recorded during refresh,
+// the file is uploaded again.
+func resourceDatabricksDbfsFileCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("source") {
+		return nil
+	}
+
+	md5Sum, sha256Sum, err := hashLocalFile(d.Get("source").(string))
+	if err != nil {
+		return err
+	}
+
+	if d.Get("md5").(string) == md5Sum && d.Get("sha256").(string) == sha256Sum {
+		return nil
+	}
+
+	if err := d.SetNew("md5", md5Sum); err != nil {
+		return err
+	}
+
+	if err := d.SetNew("sha256", sha256Sum); err != nil {
+		return err
+	}
+
+	if err := d.SetNewComputed("file_size"); err != nil {
+		return err
+	}
+
+	return d.SetNewComputed("modification_time")
+}
+
+func uploadDbfsFileFromSource(ctx context.Context, client dbfs.BaseClient, path, source string, overwrite bool) error {
+	f, err := os.Open(source)
+	if err != nil {
+		return fmt.Errorf("unable to open source: %s", err)
+	}
+	defer f.Close()
+
+	return uploadDbfsFile(ctx, client, path, f, overwrite)
+}
+
+// dbfsReadSize is the maximum number of bytes the API returns per read.
+const dbfsReadSize = 1024 * 1024
+
+// readDbfsFile writes the contents of a file to w, one read at a time.
+func readDbfsFile(ctx context.Context, client dbfs.BaseClient, path string, w io.Writer) error {
+	var offset int64
+
+	for {
+		resp, err := dbfsRead(ctx, client, path, offset, dbfsReadSize)
+		if err != nil {
+			return fmt.Errorf("unable to read file: %s", err)
+		}
+
+		bytesRead := to.Int64(resp.BytesRead)
+		if bytesRead == 0 {
+			return nil
+		}
+
+		data, err := base64.StdEncoding.DecodeString(to.String(resp.Data))
+		if err != nil {
+			return fmt.Errorf("unable to decode file contents: %s", err)
+		}
+
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+
+		offset += bytesRead
+	}
+}
+
+func hashLocalFile(path string) (md5Sum, sha256Sum string, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to open source: %s", err)
+	}
+	defer f.Close()
+
+	md5Hash := md5.New()
+	sha256Hash := sha256.New()
+
+	if _, err := io.Copy(io.MultiWriter(md5Hash, sha256Hash), f); err != nil {
+		return "", "", fmt.Errorf("unable to read source: %s", err)
+	}
+
+	return hex.EncodeToString(md5Hash.Sum(nil)), hex.EncodeToString(sha256Hash.Sum(nil)), nil
+}
@@ -0,0 +1,76 @@
+package databricks
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccDatabricksDbfsFile_basic(t *testing.T) {
+	resourceName := "databricks_dbfs_file.test"
+	path := fmt.Sprintf("/tmp/%s.txt", acctest.RandString(6))
+
+	source, err := ioutil.TempFile("", "dbfs-file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(source.Name())
+
+	if _, err := source.WriteString("Hello, world!"); err != nil {
+		t.Fatal(err)
+	}
+	source.Close()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDatabricksDbfsFileDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabricksDbfsFileBasic(path, source.Name()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "path", path),
+					resource.TestCheckResourceAttr(resourceName, "md5", "6cd3556deb0da54bca060b4c39479839"),
+					resource.TestCheckResourceAttr(resourceName, "file_size", "13"),
+					resource.TestCheckResourceAttrSet(resourceName, "modification_time"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDatabricksDbfsFileDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "databricks_dbfs_file" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*Meta).Dbfs
+		ctx := testAccProvider.Meta().(*Meta).StopContext
+		resp, err := client.GetStatus(ctx, rs.Primary.ID)
+		if err != nil {
+			if resp.IsHTTPStatus(404) {
+				return nil
+			}
+			return err
+		}
+
+		return fmt.Errorf("DBFS file still exists:\n%#v", resp)
+	}
+
+	return nil
+}
+
+func testAccDatabricksDbfsFileBasic(path, source string) string {
+	return fmt.Sprintf(`
+resource "databricks_dbfs_file" "test" {
+  path   = "%s"
+  source = "%s"
+}
+`, path, source)
+}
@@ -1,11 +1,19 @@
 package databricks
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/innovationnorway/go-databricks/databricks"
 	"github.com/innovationnorway/go-databricks/dbfs"
 )
 
@@ -13,15 +21,31 @@ func resourceDatabricksDbfsMkdirs() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceDatabricksDbfsMkdirsCreate,
 		Read:   resourceDatabricksDbfsMkdirsRead,
+		Update: resourceDatabricksDbfsMkdirsUpdate,
 		Delete: resourceDatabricksDbfsMkdirsDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"path": {
 				Type:         schema.TypeString,
 				Required:     true,
-				ForceNew:     true,
 				ValidateFunc: validation.StringIsNotEmpty,
 			},
+
+			"overwrite_on_move": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"force_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
@@ -64,21 +88,113 @@ func resourceDatabricksDbfsMkdirsRead(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
-func resourceDatabricksDbfsMkdirsDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceDatabricksDbfsMkdirsUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Meta).Dbfs
 	ctx := meta.(*Meta).StopContext
 
-	attributes := dbfs.DeleteAttributes{
-		Path:      to.StringPtr(d.Get("path").(string)),
-		Recursive: to.BoolPtr(true),
+	if d.HasChange("path") {
+		path := d.Get("path").(string)
+
+		if err := moveDbfsPath(ctx, client, d.Id(), path, d.Get("overwrite_on_move").(bool)); err != nil {
+			return err
+		}
+
+		d.SetId(path)
 	}
 
-	_, err := client.Delete(ctx, attributes)
+	return resourceDatabricksDbfsMkdirsRead(d, meta)
+}
+
+func resourceDatabricksDbfsMkdirsDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Dbfs
+	ctx := meta.(*Meta).StopContext
+
+	path := d.Get("path").(string)
+
+	resp, err := dbfsList(ctx, client, path)
 	if err != nil {
-		return fmt.Errorf("unable to delete directory: %s", err)
+		if resp.IsHTTPStatus(404) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("unable to list directory: %s", err)
+	}
+
+	var children []string
+	if resp.Files != nil {
+		for _, item := range *resp.Files {
+			children = append(children, to.String(item.Path))
+		}
+	}
+
+	if len(children) > 0 && !d.Get("force_destroy").(bool) {
+		return fmt.Errorf("unable to delete directory %q, it is not empty: %s. Set force_destroy to delete it with all of its contents", path, summarizePaths(children))
+	}
+
+	// Deleting one child at a time keeps each call well below the number of
+	// files the API deletes before it gives up with PARTIAL_DELETE.
+	for _, child := range append(children, path) {
+		if err := deleteDbfsPathRecursive(ctx, client, child, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return fmt.Errorf("unable to delete directory: %s", err)
+		}
 	}
 
 	d.SetId("")
 
 	return nil
 }
+
+// deleteDbfsPathRecursive deletes a path with all of its contents. Large
+// trees are deleted in increments, so the call is repeated with backoff for
+// as long as the API reports that only part of the tree was deleted, until
+// timeout passes or ctx is cancelled.
+func deleteDbfsPathRecursive(ctx context.Context, client dbfs.BaseClient, path string, timeout time.Duration) error {
+	attributes := dbfs.DeleteAttributes{
+		Path:      to.StringPtr(path),
+		Recursive: to.BoolPtr(true),
+	}
+
+	return resource.Retry(timeout, func() *resource.RetryError {
+		if err := ctx.Err(); err != nil {
+			return resource.NonRetryableError(fmt.Errorf("%s: %s", path, err))
+		}
+
+		resp, err := client.Delete(ctx, attributes)
+		if err == nil || resp.IsHTTPStatus(404) {
+			return nil
+		}
+
+		if !isDbfsPartialDeleteError(err) {
+			return resource.NonRetryableError(fmt.Errorf("%s: %s", path, err))
+		}
+
+		log.Printf("[DEBUG] %q was partially deleted, retrying", path)
+
+		return resource.RetryableError(fmt.Errorf("%s: %s", path, err))
+	})
+}
+
+func isDbfsPartialDeleteError(err error) bool {
+	if de, ok := err.(autorest.DetailedError); ok {
+		if de.StatusCode == http.StatusServiceUnavailable {
+			return true
+		}
+		if e, ok := de.Original.(*databricks.Error); ok {
+			return e.ErrorCode == "PARTIAL_DELETE"
+		}
+	}
+
+	return false
+}
+
+// summarizePaths lists the first few DBFS or workspace paths and the total
+// count.
+func summarizePaths(paths []string) string {
+	const max = 5
+
+	if len(paths) <= max {
+		return fmt.Sprintf("%d entries (%s)", len(paths), strings.Join(paths, ", "))
+	}
+
+	return fmt.Sprintf("%d entries (%s, ...)", len(paths), strings.Join(paths[:max], ", "))
+}
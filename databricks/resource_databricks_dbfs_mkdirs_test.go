@@ -1,13 +1,88 @@
 package databricks
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
 
+func TestDeleteDbfsPathRecursive(t *testing.T) {
+	partialDelete := map[string]interface{}{"error_code": "PARTIAL_DELETE", "message": "partially deleted"}
+
+	t.Run("partial delete", func(t *testing.T) {
+		attempts := 0
+		api := newTestAPI(map[string]testAPIHandler{
+			"POST /dbfs/delete": func(req testAPIRequest) (int, interface{}) {
+				attempts++
+				if attempts < 3 {
+					return http.StatusServiceUnavailable, partialDelete
+				}
+				return http.StatusOK, map[string]interface{}{}
+			},
+		})
+		meta := testAPIMeta(t, api)
+
+		if err := deleteDbfsPathRecursive(context.Background(), meta.Dbfs, "/tmp/dir", time.Minute); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if len(api.find("POST /dbfs/delete")) != 3 {
+			t.Errorf("expected 3 delete requests, got %v", api.calls())
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		api := newTestAPI(map[string]testAPIHandler{
+			"POST /dbfs/delete": func(req testAPIRequest) (int, interface{}) {
+				return http.StatusServiceUnavailable, partialDelete
+			},
+		})
+		meta := testAPIMeta(t, api)
+
+		if err := deleteDbfsPathRecursive(context.Background(), meta.Dbfs, "/tmp/dir", time.Second); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		api := newTestAPI(map[string]testAPIHandler{})
+		meta := testAPIMeta(t, api)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := deleteDbfsPathRecursive(ctx, meta.Dbfs, "/tmp/dir", time.Minute); err == nil {
+			t.Fatal("expected an error")
+		}
+
+		if calls := api.calls(); len(calls) != 0 {
+			t.Errorf("expected no requests, got %v", calls)
+		}
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		api := newTestAPI(map[string]testAPIHandler{
+			"POST /dbfs/delete": func(req testAPIRequest) (int, interface{}) {
+				return http.StatusForbidden, map[string]interface{}{"error_code": "PERMISSION_DENIED", "message": "denied"}
+			},
+		})
+		meta := testAPIMeta(t, api)
+
+		if err := deleteDbfsPathRecursive(context.Background(), meta.Dbfs, "/tmp/dir", time.Minute); err == nil {
+			t.Fatal("expected an error")
+		}
+
+		if len(api.find("POST /dbfs/delete")) != 1 {
+			t.Errorf("expected 1 delete request, got %v", api.calls())
+		}
+	})
+}
+
 func TestAccDatabricksDbfsMkdirs_basic(t *testing.T) {
 	resourceName := "databricks_dbfs_mkdirs.test"
 	path := "/mnt/foo"
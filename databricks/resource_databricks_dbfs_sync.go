@@ -0,0 +1,459 @@
+package databricks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/innovationnorway/go-databricks/dbfs"
+)
+
+func resourceDatabricksDbfsSync() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDatabricksDbfsSyncCreate,
+		Read:   resourceDatabricksDbfsSyncRead,
+		Update: resourceDatabricksDbfsSyncUpdate,
+		Delete: resourceDatabricksDbfsSyncDelete,
+
+		CustomizeDiff: resourceDatabricksDbfsSyncCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"source": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"path": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringIsNotEmpty,
+				DiffSuppressFunc: suppressEquivalentDbfsPaths,
+			},
+
+			"include": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateGlob,
+				},
+			},
+
+			"exclude": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateGlob,
+				},
+			},
+
+			"parallelism": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      4,
+				ValidateFunc: validation.IntBetween(1, 32),
+			},
+
+			"files": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"file_versions": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceDatabricksDbfsSyncCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Dbfs
+	ctx := meta.(*Meta).StopContext
+
+	root := normalizeDbfsPath(d.Get("path").(string))
+	source := d.Get("source").(string)
+	globs := expandSyncGlobs(d)
+
+	local, err := listLocalSyncFiles(source, globs)
+	if err != nil {
+		return err
+	}
+
+	if err := syncDbfsFiles(ctx, client, root, source, map[string]interface{}{}, local, d.Get("parallelism").(int)); err != nil {
+		return err
+	}
+
+	d.SetId(root)
+
+	return resourceDatabricksDbfsSyncRead(d, meta)
+}
+
+func resourceDatabricksDbfsSyncRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Dbfs
+	ctx := meta.(*Meta).StopContext
+
+	root := normalizeDbfsPath(d.Id())
+	globs := expandSyncGlobs(d)
+
+	// A file is only downloaded to compute its checksum when its size or
+	// modification time changed since the last refresh.
+	state := d.Get("files").(map[string]interface{})
+	stateVersions := d.Get("file_versions").(map[string]interface{})
+
+	var names []string
+	files := make(map[string]interface{})
+	versions := make(map[string]interface{})
+
+	err := walkDbfs(ctx, client, root, func(info dbfsFileInfo) error {
+		name := strings.TrimPrefix(strings.TrimPrefix(normalizeDbfsPath(to.String(info.Path)), root), "/")
+		if !globs.match(name) {
+			return nil
+		}
+
+		version := dbfsFileVersion(info)
+		if version != "" {
+			versions[name] = version
+		}
+
+		if hash, ok := state[name]; ok && version != "" && stateVersions[name] == version {
+			files[name] = hash
+			return nil
+		}
+
+		names = append(names, name)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+
+	err = runParallel(d.Get("parallelism").(int), names, func(name string) error {
+		hash := sha256.New()
+		if err := readDbfsFile(ctx, client, path.Join(root, name), hash); err != nil {
+			return fmt.Errorf("%s: %s", name, err)
+		}
+
+		mu.Lock()
+		files[name] = hex.EncodeToString(hash.Sum(nil))
+		mu.Unlock()
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.Set("path", root)
+	d.Set("files", files)
+	d.Set("file_versions", versions)
+
+	return nil
+}
+
+func resourceDatabricksDbfsSyncUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Dbfs
+	ctx := meta.(*Meta).StopContext
+
+	o, n := d.GetChange("files")
+
+	err := syncDbfsFiles(ctx, client, normalizeDbfsPath(d.Id()), d.Get("source").(string), o.(map[string]interface{}), n.(map[string]interface{}), d.Get("parallelism").(int))
+	if err != nil {
+		return err
+	}
+
+	return resourceDatabricksDbfsSyncRead(d, meta)
+}
+
+func resourceDatabricksDbfsSyncDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Dbfs
+	ctx := meta.(*Meta).StopContext
+
+	err := syncDbfsFiles(ctx, client, normalizeDbfsPath(d.Id()), d.Get("source").(string), d.Get("files").(map[string]interface{}), map[string]interface{}{}, d.Get("parallelism").(int))
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// resourceDatabricksDbfsSyncCustomizeDiff plans the checksums of the local
+// files as the new value of files. Any difference from the checksums of the
+// remote files recorded during refresh is synced on apply.
+func resourceDatabricksDbfsSyncCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("source") || !d.NewValueKnown("include") || !d.NewValueKnown("exclude") {
+		return d.SetNewComputed("files")
+	}
+
+	globs := syncGlobs{
+		Include: expandStringList(d.Get("include").([]interface{})),
+		Exclude: expandStringList(d.Get("exclude").([]interface{})),
+	}
+
+	local, err := listLocalSyncFiles(d.Get("source").(string), globs)
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(local, d.Get("files").(map[string]interface{})) {
+		return nil
+	}
+
+	return d.SetNew("files", local)
+}
+
+// syncDbfsFiles uploads every file whose checksum differs between remote
+// and local, and deletes remote files that are not in local. Both maps are
+// keyed by the path relative to root.
+func syncDbfsFiles(ctx context.Context, client dbfs.BaseClient, root, source string, remote, local map[string]interface{}, parallelism int) error {
+	var uploads, deletes []string
+	dirs := make(map[string]bool)
+
+	for name, hash := range local {
+		if v, ok := remote[name]; ok && v.(string) == hash.(string) {
+			continue
+		}
+		uploads = append(uploads, name)
+		dirs[path.Dir(path.Join(root, name))] = true
+	}
+
+	for name := range remote {
+		if _, ok := local[name]; !ok {
+			deletes = append(deletes, name)
+		}
+	}
+
+	for dir := range dirs {
+		if _, err := client.Mkdirs(ctx, dbfs.MkdirsAttributes{Path: to.StringPtr(dir)}); err != nil {
+			return fmt.Errorf("unable to create directory %q: %s", dir, err)
+		}
+	}
+
+	var result *multierror.Error
+
+	err := runParallel(parallelism, uploads, func(name string) error {
+		err := uploadDbfsFileFromSource(ctx, client, path.Join(root, name), filepath.Join(source, filepath.FromSlash(name)), true)
+		if err != nil {
+			return fmt.Errorf("%s: %s", name, err)
+		}
+		return nil
+	})
+	result = multierror.Append(result, err)
+
+	err = runParallel(parallelism, deletes, func(name string) error {
+		resp, err := client.Delete(ctx, dbfs.DeleteAttributes{Path: to.StringPtr(path.Join(root, name))})
+		if err != nil && !resp.IsHTTPStatus(404) {
+			return fmt.Errorf("%s: unable to delete file: %s", name, err)
+		}
+		return nil
+	})
+	result = multierror.Append(result, err)
+
+	return result.ErrorOrNil()
+}
+
+// normalizeDbfsPath returns path as an absolute, clean path without the
+// dbfs: scheme, the form in which the API returns paths.
+func normalizeDbfsPath(p string) string {
+	return path.Clean("/" + strings.TrimPrefix(p, "dbfs:"))
+}
+
+func suppressEquivalentDbfsPaths(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeDbfsPath(old) == normalizeDbfsPath(new)
+}
+
+// dbfsFileVersion identifies the content of a remote file by its size and
+// modification time. It is empty if the API did not return both.
+func dbfsFileVersion(info dbfsFileInfo) string {
+	if info.FileSize == nil || info.ModificationTime == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%d/%d", *info.FileSize, *info.ModificationTime)
+}
+
+// walkDbfs calls fn for every file below root.
+func walkDbfs(ctx context.Context, client dbfs.BaseClient, root string, fn func(dbfsFileInfo) error) error {
+	resp, err := dbfsList(ctx, client, root)
+	if err != nil {
+		if resp.IsHTTPStatus(404) {
+			return nil
+		}
+		return fmt.Errorf("unable to list %q: %s", root, err)
+	}
+
+	if resp.Files == nil {
+		return nil
+	}
+
+	for _, info := range *resp.Files {
+		if to.Bool(info.IsDir) {
+			if err := walkDbfs(ctx, client, to.String(info.Path), fn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := fn(info); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncGlobs selects the files of a sync resource. A pattern matches either
+// the path relative to the root, or the name of the file.
+type syncGlobs struct {
+	Include []string
+	Exclude []string
+}
+
+func (g syncGlobs) match(name string) bool {
+	if len(g.Include) > 0 && !matchGlobs(g.Include, name) {
+		return false
+	}
+
+	return !matchGlobs(g.Exclude, name)
+}
+
+func matchGlobs(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(name)); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func expandSyncGlobs(d *schema.ResourceData) syncGlobs {
+	return syncGlobs{
+		Include: expandStringList(d.Get("include").([]interface{})),
+		Exclude: expandStringList(d.Get("exclude").([]interface{})),
+	}
+}
+
+func validateGlob(val interface{}, key string) (warns []string, errs []error) {
+	if _, err := path.Match(val.(string), ""); err != nil {
+		errs = append(errs, fmt.Errorf("%q is not a valid pattern: %s", key, err))
+	}
+	return
+}
+
+// listLocalSyncFiles returns the SHA-256 checksums of the files below source
+// selected by globs, keyed by their slash separated relative path.
+func listLocalSyncFiles(source string, globs syncGlobs) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+
+	err := filepath.Walk(source, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(source, p)
+		if err != nil {
+			return err
+		}
+
+		name := filepath.ToSlash(rel)
+		if !globs.match(name) {
+			return nil
+		}
+
+		_, sha256Sum, err := hashLocalFile(p)
+		if err != nil {
+			return err
+		}
+
+		result[name] = sha256Sum
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read source directory: %s", err)
+	}
+
+	return result, nil
+}
+
+// runParallel calls fn for every item using at most parallelism goroutines.
+// It does not stop at the first failure, all errors are returned together.
+func runParallel(parallelism int, items []string, fn func(string) error) error {
+	sort.Strings(items)
+
+	work := make(chan string)
+	errs := make(chan error, len(items))
+
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range work {
+				if err := fn(item); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+
+	for _, item := range items {
+		work <- item
+	}
+	close(work)
+
+	wg.Wait()
+	close(errs)
+
+	var result *multierror.Error
+	for err := range errs {
+		result = multierror.Append(result, err)
+	}
+
+	return result.ErrorOrNil()
+}
+
+func expandStringList(input []interface{}) []string {
+	result := make([]string, 0, len(input))
+
+	for _, item := range input {
+		if v, ok := item.(string); ok && v != "" {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
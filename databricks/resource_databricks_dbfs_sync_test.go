@@ -0,0 +1,202 @@
+package databricks
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestSyncGlobsMatch(t *testing.T) {
+	globs := syncGlobs{
+		Include: []string{"*.sh", "conf/*"},
+		Exclude: []string{"*.tmp", "conf/local.*"},
+	}
+
+	cases := map[string]bool{
+		"init.sh":          true,
+		"scripts/setup.sh": true,
+		"conf/app.yaml":    true,
+		"conf/local.yaml":  false,
+		"conf/app.tmp":     false,
+		"lib/app.jar":      false,
+	}
+
+	for name, expected := range cases {
+		if actual := globs.match(name); actual != expected {
+			t.Errorf("%s: expected %t, got %t", name, expected, actual)
+		}
+	}
+}
+
+func TestNormalizeDbfsPath(t *testing.T) {
+	cases := map[string]string{
+		"/sync":          "/sync",
+		"/sync/":         "/sync",
+		"dbfs:/sync/":    "/sync",
+		"dbfs:/sync/a/.": "/sync/a",
+		"sync":           "/sync",
+		"/":              "/",
+		"dbfs:/":         "/",
+	}
+
+	for input, expected := range cases {
+		if actual := normalizeDbfsPath(input); actual != expected {
+			t.Errorf("%s: expected %q, got %q", input, expected, actual)
+		}
+	}
+}
+
+func TestResourceDatabricksDbfsSyncRead(t *testing.T) {
+	api := newTestAPI(map[string]testAPIHandler{
+		"GET /dbfs/list": func(req testAPIRequest) (int, interface{}) {
+			if req.Query.Get("path") != "/sync" {
+				return http.StatusNotFound, map[string]string{"error_code": "RESOURCE_DOES_NOT_EXIST"}
+			}
+			return http.StatusOK, map[string]interface{}{
+				"files": []map[string]interface{}{
+					{"path": "/sync/unchanged.sh", "is_dir": false, "file_size": 3, "modification_time": 100},
+					{"path": "/sync/changed.sh", "is_dir": false, "file_size": 3, "modification_time": 200},
+				},
+			}
+		},
+		"GET /dbfs/read": func(req testAPIRequest) (int, interface{}) {
+			if req.Query.Get("offset") != "0" {
+				return http.StatusOK, map[string]interface{}{"bytes_read": 0}
+			}
+			return http.StatusOK, map[string]interface{}{
+				"bytes_read": 3,
+				"data":       base64.StdEncoding.EncodeToString([]byte("new")),
+			}
+		},
+	})
+	meta := testAPIMeta(t, api)
+
+	d := schema.TestResourceDataRaw(t, resourceDatabricksDbfsSync().Schema, map[string]interface{}{
+		"path":   "dbfs:/sync/",
+		"source": "./sync",
+	})
+	d.SetId("dbfs:/sync/")
+	d.Set("files", map[string]interface{}{
+		"unchanged.sh": "unchanged-hash",
+		"changed.sh":   "stale-hash",
+	})
+	d.Set("file_versions", map[string]interface{}{
+		"unchanged.sh": "3/100",
+		"changed.sh":   "3/50",
+	})
+
+	if err := resourceDatabricksDbfsSyncRead(d, meta); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"unchanged.sh": "unchanged-hash",
+		"changed.sh":   "11507a0e2f5e69d5dfa40a62a1bd7b6ee57e6bcd85c67c9b8431b36fff21c437",
+	}
+	if actual := d.Get("files").(map[string]interface{}); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected files %v, got %v", expected, actual)
+	}
+
+	reads := api.find("GET /dbfs/read")
+	if len(reads) == 0 {
+		t.Error("expected the changed file to be read")
+	}
+
+	for _, req := range reads {
+		if path := req.Query.Get("path"); path != "/sync/changed.sh" {
+			t.Errorf("expected only the changed file to be read, got %s", path)
+		}
+	}
+
+	if actual := d.Get("file_versions").(map[string]interface{})["changed.sh"]; actual != "3/200" {
+		t.Errorf("expected the version of the changed file to be updated, got %v", actual)
+	}
+}
+
+func TestAccDatabricksDbfsSync_basic(t *testing.T) {
+	resourceName := "databricks_dbfs_sync.test"
+	path := fmt.Sprintf("/tmp/%s", acctest.RandString(6))
+
+	source, err := ioutil.TempDir("", "dbfs-sync")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(source)
+
+	for name, content := range map[string]string{
+		"init.sh":          "#!/bin/bash",
+		"conf/app.yaml":    "foo: bar",
+		"conf/ignored.tmp": "ignored",
+	} {
+		p := filepath.Join(source, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDatabricksDbfsSyncDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabricksDbfsSyncBasic(path, source),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "path", path),
+					resource.TestCheckResourceAttr(resourceName, "files.%", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "files.init.sh"),
+					resource.TestCheckResourceAttrSet(resourceName, "files.conf/app.yaml"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDatabricksDbfsSyncDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "databricks_dbfs_sync" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*Meta).Dbfs
+		ctx := testAccProvider.Meta().(*Meta).StopContext
+
+		var files []string
+		err := walkDbfs(ctx, client, rs.Primary.ID, func(info dbfsFileInfo) error {
+			files = append(files, *info.Path)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(files) > 0 {
+			return fmt.Errorf("DBFS files still exist:\n%#v", files)
+		}
+	}
+
+	return nil
+}
+
+func testAccDatabricksDbfsSyncBasic(path, source string) string {
+	return fmt.Sprintf(`
+resource "databricks_dbfs_sync" "test" {
+  path    = "%s"
+  source  = "%s"
+  exclude = ["*.tmp"]
+}
+`, path, source)
+}
@@ -1,7 +1,13 @@
 package databricks
 
 import (
+	"context"
+	"encoding/base64"
 	"fmt"
+	"io"
+	"log"
+	pathpkg "path"
+	"strings"
 
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -20,7 +26,6 @@ func resourceDatabricksDbfsUpload() *schema.Resource {
 			"path": {
 				Type:         schema.TypeString,
 				Required:     true,
-				ForceNew:     true,
 				ValidateFunc: validation.StringIsNotEmpty,
 			},
 
@@ -29,6 +34,12 @@ func resourceDatabricksDbfsUpload() *schema.Resource {
 				Required:     true,
 				ValidateFunc: validation.StringIsBase64,
 			},
+
+			"overwrite_on_move": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
@@ -38,18 +49,10 @@ func resourceDatabricksDbfsUploadCreate(d *schema.ResourceData, meta interface{}
 	ctx := meta.(*Meta).StopContext
 
 	path := d.Get("path").(string)
+	contents := base64.NewDecoder(base64.StdEncoding, strings.NewReader(d.Get("contents").(string)))
 
-	attributes := dbfs.PutAttributes{
-		Path: &path,
-	}
-
-	if v, ok := d.GetOk("contents"); ok {
-		attributes.Contents = to.StringPtr(v.(string))
-	}
-
-	_, err := client.Put(ctx, attributes)
-	if err != nil {
-		return fmt.Errorf("unable to upload file: %s", err)
+	if err := uploadDbfsFile(ctx, client, path, contents, false); err != nil {
+		return err
 	}
 
 	d.SetId(path)
@@ -83,18 +86,21 @@ func resourceDatabricksDbfsUploadUpdate(d *schema.ResourceData, meta interface{}
 
 	path := d.Get("path").(string)
 
-	attributes := dbfs.PutAttributes{
-		Path:      &path,
-		Overwrite: to.BoolPtr(true),
-	}
+	if d.HasChange("path") {
+		o, _ := d.GetChange("path")
+		if err := moveDbfsPath(ctx, client, o.(string), path, d.Get("overwrite_on_move").(bool)); err != nil {
+			return err
+		}
 
-	if v, ok := d.GetOk("contents"); ok {
-		attributes.Contents = to.StringPtr(v.(string))
+		d.SetId(path)
 	}
 
-	_, err := client.Put(ctx, attributes)
-	if err != nil {
-		return fmt.Errorf("unable to upload file: %s", err)
+	if d.HasChange("contents") {
+		contents := base64.NewDecoder(base64.StdEncoding, strings.NewReader(d.Get("contents").(string)))
+
+		if err := uploadDbfsFile(ctx, client, path, contents, true); err != nil {
+			return err
+		}
 	}
 
 	return resourceDatabricksDbfsUploadRead(d, meta)
@@ -119,3 +125,129 @@ func resourceDatabricksDbfsUploadDelete(d *schema.ResourceData, meta interface{}
 
 	return nil
 }
+
+// dbfsBlockSize is the number of bytes sent per request. It keeps the base64
+// encoded data of a single request below the 1 MB limit of the API.
+const dbfsBlockSize = 512 * 1024
+
+// uploadDbfsFile writes the data read from r to path. Data that fits in one
+// block is sent with a single put request, anything larger is streamed one
+// block at a time so that only one block is held in memory.
+func uploadDbfsFile(ctx context.Context, client dbfs.BaseClient, path string, r io.Reader, overwrite bool) error {
+	buf := make([]byte, dbfsBlockSize)
+
+	n, err := io.ReadFull(r, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		attributes := dbfs.PutAttributes{
+			Path:      to.StringPtr(path),
+			Contents:  to.StringPtr(base64.StdEncoding.EncodeToString(buf[:n])),
+			Overwrite: to.BoolPtr(overwrite),
+		}
+
+		if _, err := client.Put(ctx, attributes); err != nil {
+			return fmt.Errorf("unable to upload file: %s", err)
+		}
+
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read file: %s", err)
+	}
+
+	resp, err := dbfsCreate(ctx, client, dbfsCreateAttributes{
+		Path:      to.StringPtr(path),
+		Overwrite: to.BoolPtr(overwrite),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create file: %s", err)
+	}
+
+	handle := resp.Handle
+
+	for {
+		attributes := dbfsAddBlockAttributes{
+			Handle: handle,
+			Data:   to.StringPtr(base64.StdEncoding.EncodeToString(buf[:n])),
+		}
+
+		if _, err := dbfsAddBlock(ctx, client, attributes); err != nil {
+			return abortDbfsUpload(client, path, handle, fmt.Errorf("unable to upload block: %s", err))
+		}
+
+		n, err = io.ReadFull(r, buf)
+		if err == io.EOF {
+			break
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return abortDbfsUpload(client, path, handle, fmt.Errorf("unable to read file: %s", err))
+		}
+	}
+
+	if _, err := dbfsClose(ctx, client, dbfsCloseAttributes{Handle: handle}); err != nil {
+		return abortDbfsUpload(client, path, nil, fmt.Errorf("unable to close file: %s", err))
+	}
+
+	return nil
+}
+
+// abortDbfsUpload closes the handle of a failed upload and removes the
+// partially written file. A fresh context is used, so the cleanup also runs
+// when the upload failed because Terraform is being interrupted.
+func abortDbfsUpload(client dbfs.BaseClient, path string, handle *int64, cause error) error {
+	ctx := context.Background()
+
+	if handle != nil {
+		if _, err := dbfsClose(ctx, client, dbfsCloseAttributes{Handle: handle}); err != nil {
+			log.Printf("[WARN] Unable to close handle of %q: %s", path, err)
+		}
+	}
+
+	if _, err := client.Delete(ctx, dbfs.DeleteAttributes{Path: to.StringPtr(path)}); err != nil {
+		log.Printf("[WARN] Unable to delete partially uploaded %q: %s", path, err)
+	}
+
+	return cause
+}
+
+// moveDbfsPath renames a file or directory in place. An existing file at the
+// destination is only replaced when overwrite is set. An existing directory
+// is never replaced, as that would delete everything below it.
+func moveDbfsPath(ctx context.Context, client dbfs.BaseClient, source, destination string, overwrite bool) error {
+	resp, err := client.GetStatus(ctx, destination)
+	if err == nil {
+		if to.Bool(resp.IsDir) {
+			return fmt.Errorf("unable to move %q, destination %q is an existing directory", source, destination)
+		}
+
+		if !overwrite {
+			return fmt.Errorf("unable to move %q, destination %q already exists. Set overwrite_on_move to replace it", source, destination)
+		}
+
+		attributes := dbfs.DeleteAttributes{
+			Path:      to.StringPtr(destination),
+			Recursive: to.BoolPtr(false),
+		}
+
+		if _, err := client.Delete(ctx, attributes); err != nil {
+			return fmt.Errorf("unable to delete destination %q: %s", destination, err)
+		}
+	} else if !resp.IsHTTPStatus(404) {
+		return fmt.Errorf("unable to get destination status: %s", err)
+	}
+
+	parent := pathpkg.Dir(destination)
+	if _, err := client.Mkdirs(ctx, dbfs.MkdirsAttributes{Path: to.StringPtr(parent)}); err != nil {
+		return fmt.Errorf("unable to create directory %q: %s", parent, err)
+	}
+
+	attributes := dbfsMoveAttributes{
+		SourcePath:      to.StringPtr(source),
+		DestinationPath: to.StringPtr(destination),
+	}
+
+	if _, err := dbfsMove(ctx, client, attributes); err != nil {
+		return fmt.Errorf("unable to move %q to %q: %s", source, destination, err)
+	}
+
+	return nil
+}
@@ -1,7 +1,12 @@
 package databricks
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
 	"fmt"
+	"net/http"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
@@ -27,6 +32,168 @@ func TestAccDatabricksDbfsUpload_basic(t *testing.T) {
 	})
 }
 
+func TestUploadDbfsFile(t *testing.T) {
+	cases := []struct {
+		name     string
+		size     int
+		expected []string
+	}{
+		{"empty", 0, []string{"POST /dbfs/put"}},
+		{"one block", dbfsBlockSize - 1, []string{"POST /dbfs/put"}},
+		{"exact blocks", dbfsBlockSize * 2, []string{"POST /dbfs/create", "POST /dbfs/add-block", "POST /dbfs/add-block", "POST /dbfs/close"}},
+		{"partial block", dbfsBlockSize*2 + 1, []string{"POST /dbfs/create", "POST /dbfs/add-block", "POST /dbfs/add-block", "POST /dbfs/add-block", "POST /dbfs/close"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var uploaded bytes.Buffer
+
+			decode := func(req testAPIRequest, key string) {
+				data, err := base64.StdEncoding.DecodeString(req.Body[key].(string))
+				if err != nil {
+					t.Fatalf("unable to decode %s: %s", key, err)
+				}
+				uploaded.Write(data)
+			}
+
+			api := newTestAPI(map[string]testAPIHandler{
+				"POST /dbfs/put": func(req testAPIRequest) (int, interface{}) {
+					if v, ok := req.Body["contents"]; ok && v != nil {
+						decode(req, "contents")
+					}
+					return http.StatusOK, map[string]interface{}{}
+				},
+				"POST /dbfs/create": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{"handle": 7}
+				},
+				"POST /dbfs/add-block": func(req testAPIRequest) (int, interface{}) {
+					decode(req, "data")
+					return http.StatusOK, map[string]interface{}{}
+				},
+				"POST /dbfs/close": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{}
+				},
+			})
+			meta := testAPIMeta(t, api)
+
+			content := bytes.Repeat([]byte("x"), c.size)
+			if err := uploadDbfsFile(context.Background(), meta.Dbfs, "/tmp/file", bytes.NewReader(content), true); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if actual := api.calls(); !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected calls %v, got %v", c.expected, actual)
+			}
+
+			if !bytes.Equal(uploaded.Bytes(), content) {
+				t.Errorf("expected %d bytes to be uploaded, got %d", len(content), uploaded.Len())
+			}
+		})
+	}
+}
+
+func TestUploadDbfsFile_abort(t *testing.T) {
+	cases := []struct {
+		name     string
+		failing  string
+		expected []string
+	}{
+		{"add block", "POST /dbfs/add-block", []string{"POST /dbfs/create", "POST /dbfs/add-block", "POST /dbfs/close", "POST /dbfs/delete"}},
+		{"close", "POST /dbfs/close", []string{"POST /dbfs/create", "POST /dbfs/add-block", "POST /dbfs/add-block", "POST /dbfs/close", "POST /dbfs/delete"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ok := func(req testAPIRequest) (int, interface{}) {
+				return http.StatusOK, map[string]interface{}{}
+			}
+
+			handlers := map[string]testAPIHandler{
+				"POST /dbfs/create": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{"handle": 7}
+				},
+				"POST /dbfs/add-block": ok,
+				"POST /dbfs/close":     ok,
+				"POST /dbfs/delete":    ok,
+			}
+			handlers[c.failing] = func(req testAPIRequest) (int, interface{}) {
+				return http.StatusBadRequest, map[string]interface{}{"error_code": "INVALID_PARAMETER_VALUE", "message": "failed"}
+			}
+
+			api := newTestAPI(handlers)
+			meta := testAPIMeta(t, api)
+
+			content := bytes.Repeat([]byte("x"), dbfsBlockSize*2)
+			if err := uploadDbfsFile(context.Background(), meta.Dbfs, "/tmp/file", bytes.NewReader(content), true); err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if actual := api.calls(); !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected calls %v, got %v", c.expected, actual)
+			}
+
+			if deletes := api.find("POST /dbfs/delete"); len(deletes) != 1 || deletes[0].Body["path"] != "/tmp/file" {
+				t.Errorf("expected the partial file to be deleted, got %v", deletes)
+			}
+		})
+	}
+}
+
+func TestMoveDbfsPath(t *testing.T) {
+	cases := []struct {
+		name        string
+		destination map[string]interface{}
+		overwrite   bool
+		expectError bool
+		expected    []string
+	}{
+		{"missing destination", nil, false, false, []string{"GET /dbfs/get-status", "POST /dbfs/mkdirs", "POST /dbfs/move"}},
+		{"file without overwrite", map[string]interface{}{"path": "/new", "is_dir": false}, false, true, []string{"GET /dbfs/get-status"}},
+		{"file with overwrite", map[string]interface{}{"path": "/new", "is_dir": false}, true, false, []string{"GET /dbfs/get-status", "POST /dbfs/delete", "POST /dbfs/mkdirs", "POST /dbfs/move"}},
+		{"directory with overwrite", map[string]interface{}{"path": "/new", "is_dir": true}, true, true, []string{"GET /dbfs/get-status"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ok := func(req testAPIRequest) (int, interface{}) {
+				return http.StatusOK, map[string]interface{}{}
+			}
+
+			handlers := map[string]testAPIHandler{
+				"POST /dbfs/delete": ok,
+				"POST /dbfs/mkdirs": ok,
+				"POST /dbfs/move":   ok,
+			}
+			if c.destination != nil {
+				handlers["GET /dbfs/get-status"] = func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, c.destination
+				}
+			}
+
+			api := newTestAPI(handlers)
+			meta := testAPIMeta(t, api)
+
+			err := moveDbfsPath(context.Background(), meta.Dbfs, "/old", "/new", c.overwrite)
+			if c.expectError && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !c.expectError && err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if actual := api.calls(); !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected calls %v, got %v", c.expected, actual)
+			}
+
+			for _, req := range api.find("POST /dbfs/delete") {
+				if req.Body["recursive"] == true {
+					t.Error("expected the destination to be deleted without recursion")
+				}
+			}
+		})
+	}
+}
+
 func testAccCheckDatabricksDbfsUploadDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "databricks_dbfs_upload" {
@@ -0,0 +1,138 @@
+package databricks
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/innovationnorway/go-databricks/workspace"
+)
+
+func resourceDatabricksDirectory() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDatabricksDirectoryCreate,
+		Read:   resourceDatabricksDirectoryRead,
+		Update: resourceDatabricksDirectoryUpdate,
+		Delete: resourceDatabricksDirectoryDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"delete_recursive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"object_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDatabricksDirectoryCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	path := d.Get("path").(string)
+
+	attributes := workspace.MkdirsAttributes{
+		Path: to.StringPtr(path),
+	}
+
+	_, err := client.Mkdirs(ctx, attributes)
+	if err != nil {
+		return fmt.Errorf("unable to create directory: %s", err)
+	}
+
+	d.SetId(path)
+
+	return resourceDatabricksDirectoryRead(d, meta)
+}
+
+func resourceDatabricksDirectoryRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	resp, err := client.GetStatus(ctx, d.Id())
+	if err != nil {
+		if resp.IsHTTPStatus(404) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("unable to get object status: %s", err)
+	}
+
+	if resp.ObjectType != workspace.DIRECTORY {
+		return fmt.Errorf("%q is a %s, not a %s", d.Id(), resp.ObjectType, workspace.DIRECTORY)
+	}
+
+	d.Set("path", resp.Path)
+
+	if err := d.Set("object_id", strconv.FormatInt(to.Int64(resp.ObjectID), 10)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceDatabricksDirectoryUpdate(d *schema.ResourceData, meta interface{}) error {
+	// Only delete_recursive can be updated, and it is only used on delete.
+	return resourceDatabricksDirectoryRead(d, meta)
+}
+
+func resourceDatabricksDirectoryDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	path := d.Id()
+	recursive := d.Get("delete_recursive").(bool)
+
+	if !recursive {
+		resp, err := client.List(ctx, path)
+		if err != nil {
+			if resp.IsHTTPStatus(404) {
+				d.SetId("")
+				return nil
+			}
+			return fmt.Errorf("unable to list directory: %s", err)
+		}
+
+		var children []string
+		if resp.Objects != nil {
+			for _, item := range *resp.Objects {
+				children = append(children, to.String(item.Path))
+			}
+		}
+
+		if len(children) > 0 {
+			return fmt.Errorf("unable to delete directory %q, it is not empty: %s. Set delete_recursive to delete it with all of its contents", path, summarizePaths(children))
+		}
+	}
+
+	attributes := workspace.DeleteAttributes{
+		Path:      to.StringPtr(path),
+		Recursive: to.BoolPtr(recursive),
+	}
+
+	resp, err := client.Delete(ctx, attributes)
+	if err != nil && !resp.IsHTTPStatus(404) {
+		return fmt.Errorf("unable to delete directory: %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
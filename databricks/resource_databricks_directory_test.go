@@ -0,0 +1,94 @@
+package databricks
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccDatabricksDirectory_basic(t *testing.T) {
+	resourceName := "databricks_directory.test"
+	path := fmt.Sprintf("/Shared/%s", acctest.RandString(6))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDatabricksDirectoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabricksDirectoryBasic(path),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "path", path),
+					resource.TestCheckResourceAttrSet(resourceName, "object_id"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"delete_recursive"},
+			},
+		},
+	})
+}
+
+func TestResourceDatabricksDirectoryRead(t *testing.T) {
+	api := newTestAPI(map[string]testAPIHandler{
+		"GET /workspace/get-status": func(req testAPIRequest) (int, interface{}) {
+			return http.StatusOK, map[string]interface{}{
+				"path":        req.Query.Get("path"),
+				"object_type": "DIRECTORY",
+				"object_id":   1234567890123,
+			}
+		},
+	})
+	meta := testAPIMeta(t, api)
+
+	d := schema.TestResourceDataRaw(t, resourceDatabricksDirectory().Schema, map[string]interface{}{
+		"path": "/Shared/example",
+	})
+	d.SetId("/Shared/example")
+
+	if err := resourceDatabricksDirectoryRead(d, meta); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if actual := d.Get("object_id").(string); actual != "1234567890123" {
+		t.Errorf("expected object_id %q, got %q", "1234567890123", actual)
+	}
+}
+
+func testAccCheckDatabricksDirectoryDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "databricks_directory" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*Meta).Workspace
+		ctx := testAccProvider.Meta().(*Meta).StopContext
+		resp, err := client.GetStatus(ctx, rs.Primary.ID)
+		if err != nil {
+			if resp.IsHTTPStatus(404) {
+				return nil
+			}
+			return err
+		}
+
+		return fmt.Errorf("Databricks directory still exists:\n%#v", resp)
+	}
+
+	return nil
+}
+
+func testAccDatabricksDirectoryBasic(path string) string {
+	return fmt.Sprintf(`
+resource "databricks_directory" "test" {
+  path = "%s"
+}
+`, path)
+}
@@ -0,0 +1,114 @@
+package databricks
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceDatabricksGitCredential() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDatabricksGitCredentialCreate,
+		Read:   resourceDatabricksGitCredentialRead,
+		Update: resourceDatabricksGitCredentialUpdate,
+		Delete: resourceDatabricksGitCredentialDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"git_provider": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(gitProviders, false),
+			},
+
+			"git_username": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"personal_access_token": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
+
+func resourceDatabricksGitCredentialCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	resp, err := createGitCredential(ctx, client, expandGitCredential(d))
+	if err != nil {
+		return fmt.Errorf("unable to create Git credential: %s", err)
+	}
+
+	d.SetId(strconv.FormatInt(to.Int64(resp.CredentialID), 10))
+
+	return resourceDatabricksGitCredentialRead(d, meta)
+}
+
+func resourceDatabricksGitCredentialRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	resp, err := getGitCredential(ctx, client, d.Id())
+	if err != nil {
+		if resp.IsHTTPStatus(404) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("unable to get Git credential: %s", err)
+	}
+
+	d.Set("git_provider", resp.GitProvider)
+	d.Set("git_username", resp.GitUsername)
+
+	return nil
+}
+
+func resourceDatabricksGitCredentialUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	if _, err := updateGitCredential(ctx, client, d.Id(), expandGitCredential(d)); err != nil {
+		return fmt.Errorf("unable to update Git credential: %s", err)
+	}
+
+	return resourceDatabricksGitCredentialRead(d, meta)
+}
+
+func resourceDatabricksGitCredentialDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	resp, err := deleteGitCredential(ctx, client, d.Id())
+	if err != nil && !resp.IsHTTPStatus(404) {
+		return fmt.Errorf("unable to delete Git credential: %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func expandGitCredential(d *schema.ResourceData) gitCredentialAttributes {
+	attributes := gitCredentialAttributes{
+		GitProvider:         to.StringPtr(d.Get("git_provider").(string)),
+		PersonalAccessToken: to.StringPtr(d.Get("personal_access_token").(string)),
+	}
+
+	if v, ok := d.GetOk("git_username"); ok {
+		attributes.GitUsername = to.StringPtr(v.(string))
+	}
+
+	return attributes
+}
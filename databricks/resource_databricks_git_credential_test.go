@@ -0,0 +1,146 @@
+package databricks
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestResourceDatabricksGitCredentialCreate(t *testing.T) {
+	api := newTestAPI(map[string]testAPIHandler{
+		"POST /git-credentials": func(req testAPIRequest) (int, interface{}) {
+			return http.StatusOK, map[string]interface{}{"credential_id": 123, "git_provider": "gitHub", "git_username": "example"}
+		},
+		"GET /git-credentials/123": func(req testAPIRequest) (int, interface{}) {
+			return http.StatusOK, map[string]interface{}{"credential_id": 123, "git_provider": "gitHub", "git_username": "example"}
+		},
+	})
+	meta := testAPIMeta(t, api)
+
+	d := schema.TestResourceDataRaw(t, resourceDatabricksGitCredential().Schema, map[string]interface{}{
+		"git_provider":          "gitHub",
+		"git_username":          "example",
+		"personal_access_token": "secret",
+	})
+
+	if err := resourceDatabricksGitCredentialCreate(d, meta); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if d.Id() != "123" {
+		t.Errorf("expected ID %q, got %q", "123", d.Id())
+	}
+
+	expected := map[string]interface{}{
+		"git_provider":          "gitHub",
+		"git_username":          "example",
+		"personal_access_token": "secret",
+	}
+
+	requests := api.find("POST /git-credentials")
+	if len(requests) != 1 || !reflect.DeepEqual(requests[0].Body, expected) {
+		t.Errorf("expected one request with:\n%#v\ngot:\n%#v", expected, requests)
+	}
+
+	// The token is never returned, so the configured one is kept.
+	if actual := d.Get("personal_access_token").(string); actual != "secret" {
+		t.Errorf("expected the configured token to be kept, got %q", actual)
+	}
+}
+
+func TestResourceDatabricksGitCredentialRead(t *testing.T) {
+	cases := []struct {
+		name     string
+		exists   bool
+		expectID string
+	}{
+		{"exists", true, "123"},
+		{"deleted", false, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			handlers := map[string]testAPIHandler{}
+			if c.exists {
+				handlers["GET /git-credentials/123"] = func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{"credential_id": 123, "git_provider": "gitLab", "git_username": "other"}
+				}
+			}
+
+			api := newTestAPI(handlers)
+			meta := testAPIMeta(t, api)
+
+			d := schema.TestResourceDataRaw(t, resourceDatabricksGitCredential().Schema, map[string]interface{}{
+				"git_provider":          "gitHub",
+				"git_username":          "example",
+				"personal_access_token": "secret",
+			})
+			d.SetId("123")
+
+			if err := resourceDatabricksGitCredentialRead(d, meta); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if d.Id() != c.expectID {
+				t.Errorf("expected ID %q, got %q", c.expectID, d.Id())
+			}
+
+			if c.exists && (d.Get("git_provider").(string) != "gitLab" || d.Get("git_username").(string) != "other") {
+				t.Errorf("expected the remote provider and username, got %q and %q", d.Get("git_provider"), d.Get("git_username"))
+			}
+		})
+	}
+}
+
+func TestResourceDatabricksGitCredentialUpdate(t *testing.T) {
+	api := newTestAPI(map[string]testAPIHandler{
+		"PATCH /git-credentials/123": func(req testAPIRequest) (int, interface{}) {
+			return http.StatusOK, map[string]interface{}{}
+		},
+		"GET /git-credentials/123": func(req testAPIRequest) (int, interface{}) {
+			return http.StatusOK, map[string]interface{}{"credential_id": 123, "git_provider": "gitHub"}
+		},
+	})
+	meta := testAPIMeta(t, api)
+
+	d := schema.TestResourceDataRaw(t, resourceDatabricksGitCredential().Schema, map[string]interface{}{
+		"git_provider":          "gitHub",
+		"personal_access_token": "rotated",
+	})
+	d.SetId("123")
+
+	if err := resourceDatabricksGitCredentialUpdate(d, meta); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"git_provider":          "gitHub",
+		"personal_access_token": "rotated",
+	}
+
+	requests := api.find("PATCH /git-credentials/123")
+	if len(requests) != 1 || !reflect.DeepEqual(requests[0].Body, expected) {
+		t.Errorf("expected one request with:\n%#v\ngot:\n%#v", expected, requests)
+	}
+}
+
+func TestResourceDatabricksGitCredentialDelete(t *testing.T) {
+	api := newTestAPI(nil)
+	meta := testAPIMeta(t, api)
+
+	d := schema.TestResourceDataRaw(t, resourceDatabricksGitCredential().Schema, map[string]interface{}{
+		"git_provider":          "gitHub",
+		"personal_access_token": "secret",
+	})
+	d.SetId("123")
+
+	if err := resourceDatabricksGitCredentialDelete(d, meta); err != nil {
+		t.Fatalf("expected a deleted credential to be ignored, got %s", err)
+	}
+
+	if calls := api.calls(); !reflect.DeepEqual(calls, []string{"DELETE /git-credentials/123"}) {
+		t.Errorf("expected the credential to be deleted, got %v", calls)
+	}
+}
@@ -2,25 +2,75 @@ package databricks
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"github.com/innovationnorway/go-databricks/groups"
 )
 
 func resourceDatabricksGroup() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceDatabricksGroupCreate,
 		Read:   resourceDatabricksGroupRead,
+		Update: resourceDatabricksGroupUpdate,
 		Delete: resourceDatabricksGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceDatabricksGroupV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceDatabricksGroupStateUpgradeV0,
+				Version: 0,
+			},
+		},
 
 		Schema: map[string]*schema.Schema{
+			"display_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"name", "display_name"},
+			},
+
 			"name": {
 				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
+				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringIsNotEmpty,
+				Deprecated:   "use display_name instead",
+			},
+
+			"allow_cluster_create": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"allow_instance_pool_create": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"workspace_access": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			"instance_profile_arn": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Set: schema.HashString,
 			},
 		},
 	}
@@ -30,17 +80,19 @@ func resourceDatabricksGroupCreate(d *schema.ResourceData, meta interface{}) err
 	client := meta.(*Meta).Groups
 	ctx := meta.(*Meta).StopContext
 
-	attributes := groups.Attributes{
-		GroupName: to.StringPtr(d.Get("name").(string)),
+	attributes := scimGroup{
+		Schemas:      []string{scimGroupSchema},
+		DisplayName:  to.StringPtr(getDatabricksGroupDisplayName(d)),
+		Entitlements: expandScimEntitlements(d),
+		Roles:        expandScimValues(d.Get("instance_profile_arn").(*schema.Set).List()),
 	}
 
-	resp, err := client.Create(ctx, attributes)
+	resp, err := createScimGroup(ctx, client, attributes)
 	if err != nil {
 		return fmt.Errorf("unable to create group: %s", err)
 	}
 
-	d.Set("name", resp.GroupName)
-	d.SetId(to.String(resp.GroupName))
+	d.SetId(to.String(resp.ID))
 
 	return resourceDatabricksGroupRead(d, meta)
 }
@@ -49,32 +101,66 @@ func resourceDatabricksGroupRead(d *schema.ResourceData, meta interface{}) error
 	client := meta.(*Meta).Groups
 	ctx := meta.(*Meta).StopContext
 
-	name := d.Get("name").(string)
-
-	resp, err := client.ListMembers(ctx, name)
+	resp, err := getScimGroup(ctx, client, d.Id())
 	if err != nil {
 		if resp.IsHTTPStatus(404) {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("unable to get group members: %s", err)
+		return fmt.Errorf("unable to get group: %s", err)
 	}
 
-	d.Set("name", name)
+	d.Set("display_name", resp.DisplayName)
+	d.Set("name", resp.DisplayName)
+	d.Set("instance_profile_arn", flattenScimValues(resp.Roles))
+	flattenScimEntitlements(d, resp.Entitlements)
 
 	return nil
 }
 
-func resourceDatabricksGroupDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceDatabricksGroupUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Meta).Groups
 	ctx := meta.(*Meta).StopContext
 
-	attributes := groups.DeleteAttributes{
-		GroupName: to.StringPtr(d.Get("name").(string)),
+	var operations []scimPatchOperation
+
+	// Renaming keeps the ID of the group, and with it the permissions granted
+	// to the group.
+	if d.HasChange("display_name") || d.HasChange("name") {
+		operations = append(operations, scimPatchOperation{Op: "replace", Path: "displayName", Value: getDatabricksGroupDisplayName(d)})
 	}
 
-	_, err := client.Delete(ctx, attributes)
-	if err != nil {
+	operations = append(operations, scimEntitlementPatchOperations(d)...)
+
+	if d.HasChange("instance_profile_arn") {
+		o, n := d.GetChange("instance_profile_arn")
+		add := n.(*schema.Set).Difference(o.(*schema.Set)).List()
+		remove := o.(*schema.Set).Difference(n.(*schema.Set)).List()
+
+		if len(add) > 0 {
+			operations = append(operations, scimPatchOperation{Op: "add", Path: "roles", Value: expandScimValues(add)})
+		}
+
+		for _, arn := range remove {
+			operations = append(operations, scimPatchOperation{Op: "remove", Path: fmt.Sprintf("roles[value eq %q]", arn.(string))})
+		}
+	}
+
+	if len(operations) > 0 {
+		if _, err := patchScimGroup(ctx, client, d.Id(), newScimPatchRequest(operations)); err != nil {
+			return fmt.Errorf("unable to update group: %s", err)
+		}
+	}
+
+	return resourceDatabricksGroupRead(d, meta)
+}
+
+func resourceDatabricksGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Groups
+	ctx := meta.(*Meta).StopContext
+
+	resp, err := deleteScimGroup(ctx, client, d.Id())
+	if err != nil && !resp.IsHTTPStatus(404) {
 		return fmt.Errorf("unable to delete group: %s", err)
 	}
 
@@ -82,3 +168,92 @@ func resourceDatabricksGroupDelete(d *schema.ResourceData, meta interface{}) err
 
 	return nil
 }
+
+// getDatabricksGroupDisplayName returns the configured name, which is set
+// in display_name or in the deprecated name.
+func getDatabricksGroupDisplayName(d *schema.ResourceData) string {
+	if d.HasChange("name") {
+		if v, ok := d.GetOk("name"); ok {
+			return v.(string)
+		}
+	}
+
+	return d.Get("display_name").(string)
+}
+
+func resourceDatabricksGroupV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+// resourceDatabricksGroupStateUpgradeV0 replaces the group name used as ID
+// by the Groups API with the SCIM ID of the group.
+func resourceDatabricksGroupStateUpgradeV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	client := meta.(*Meta).Groups
+	ctx := meta.(*Meta).StopContext
+
+	name, _ := rawState["name"].(string)
+	if name == "" {
+		return nil, fmt.Errorf("unable to upgrade group %q, name is missing", rawState["id"])
+	}
+
+	resp, err := listScimGroups(ctx, client, scimEqualFilter("displayName", name))
+	if err != nil {
+		return nil, fmt.Errorf("unable to upgrade group %q: %s", name, err)
+	}
+
+	// The filter may match case insensitively, so only a group with exactly
+	// the same name is used.
+	var ids []string
+	if resp.Resources != nil {
+		for _, group := range *resp.Resources {
+			if to.String(group.DisplayName) == name && group.ID != nil {
+				ids = append(ids, *group.ID)
+			}
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return nil, fmt.Errorf("unable to upgrade group %q, it does not exist", name)
+	case 1:
+	default:
+		return nil, fmt.Errorf("unable to upgrade group %q, %d groups have that name: %s", name, len(ids), strings.Join(ids, ", "))
+	}
+
+	rawState["id"] = ids[0]
+	rawState["display_name"] = name
+
+	return rawState, nil
+}
+
+func expandScimValues(input []interface{}) *[]scimValue {
+	result := make([]scimValue, 0, len(input))
+
+	for _, item := range input {
+		result = append(result, scimValue{Value: to.StringPtr(item.(string))})
+	}
+
+	return &result
+}
+
+func flattenScimValues(input *[]scimValue) []interface{} {
+	result := make([]interface{}, 0)
+
+	if input == nil {
+		return result
+	}
+
+	for _, item := range *input {
+		result = append(result, to.String(item.Value))
+	}
+
+	return result
+}
@@ -15,6 +15,8 @@ func resourceDatabricksGroupMember() *schema.Resource {
 		Read:   resourceDatabricksGroupMemberRead,
 		Delete: resourceDatabricksGroupMemberDelete,
 
+		CustomizeDiff: resourceDatabricksGroupMemberCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"parent_name": {
 				Type:         schema.TypeString,
@@ -35,7 +37,14 @@ func resourceDatabricksGroupMember() *schema.Resource {
 				Optional:     true,
 				ForceNew:     true,
 				ValidateFunc: validation.StringIsNotEmpty,
-				ExactlyOneOf: []string{"user_name", "group_name"},
+				ExactlyOneOf: []string{"user_name", "group_name", "service_principal_name"},
+			},
+
+			"service_principal_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
 			},
 		},
 	}
@@ -46,19 +55,18 @@ func resourceDatabricksGroupMemberCreate(d *schema.ResourceData, meta interface{
 	ctx := meta.(*Meta).StopContext
 
 	parentName := d.Get("parent_name").(string)
-	username := d.Get("user_name").(string)
-	groupName := d.Get("group_name").(string)
+	principalName := getPrincipalName(d)
 
-	attributes := groups.MemberAttributes{
-		ParentName: &parentName,
-	}
+	id := getDatabricksGroupMemberID(parentName, d.Get("user_name").(string), d.Get("group_name").(string), d.Get("service_principal_name").(string))
 
-	if username != "" {
-		attributes.UserName = &username
+	if err := groupMemberships.manage(meta, parentName, groupMembershipIndividual, id); err != nil {
+		return err
 	}
 
-	if groupName != "" {
-		attributes.GroupName = &groupName
+	attributes := groups.MemberAttributes{
+		ParentName: &parentName,
+		UserName:   principalName.UserName,
+		GroupName:  principalName.GroupName,
 	}
 
 	_, err := client.AddMember(ctx, attributes)
@@ -66,7 +74,7 @@ func resourceDatabricksGroupMemberCreate(d *schema.ResourceData, meta interface{
 		return fmt.Errorf("unable to add member: %s", err)
 	}
 
-	d.SetId(getDatabricksGroupMemberID(parentName, username, groupName))
+	d.SetId(id)
 
 	return resourceDatabricksGroupMemberRead(d, meta)
 }
@@ -80,13 +88,18 @@ func resourceDatabricksGroupMemberRead(d *schema.ResourceData, meta interface{})
 
 	resp, err := client.ListMembers(ctx, parentName)
 	if err != nil {
-		if resp.IsHTTPStatus(404) || !isPrincipalMemberOf(principalName, resp.Members) {
+		if resp.IsHTTPStatus(404) {
 			d.SetId("")
 			return nil
 		}
 		return fmt.Errorf("unable to get member: %s", err)
 	}
 
+	if !isPrincipalMemberOf(principalName, resp.Members) {
+		d.SetId("")
+		return nil
+	}
+
 	return nil
 }
 
@@ -96,16 +109,12 @@ func resourceDatabricksGroupMemberDelete(d *schema.ResourceData, meta interface{
 
 	parentName := d.Get("parent_name").(string)
 
+	principalName := getPrincipalName(d)
+
 	attributes := groups.MemberAttributes{
 		ParentName: &parentName,
-	}
-
-	if v, ok := d.GetOk("user_name"); ok {
-		attributes.UserName = to.StringPtr(v.(string))
-	}
-
-	if v, ok := d.GetOk("group_name"); ok {
-		attributes.GroupName = to.StringPtr(v.(string))
+		UserName:   principalName.UserName,
+		GroupName:  principalName.GroupName,
 	}
 
 	_, err := client.RemoveMember(ctx, attributes)
@@ -113,19 +122,38 @@ func resourceDatabricksGroupMemberDelete(d *schema.ResourceData, meta interface{
 		return fmt.Errorf("unable to remove member: %s", err)
 	}
 
+	groupMemberships.unregister(meta, parentName, groupMembershipIndividual, d.Id())
+
 	d.SetId("")
 
 	return nil
 }
 
-func getDatabricksGroupMemberID(parentName, userName, groupName string) string {
+func resourceDatabricksGroupMemberCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("parent_name") {
+		return nil
+	}
+
+	parentName := d.Get("parent_name").(string)
+	id := getDatabricksGroupMemberID(parentName, d.Get("user_name").(string), d.Get("group_name").(string), d.Get("service_principal_name").(string))
+
+	return groupMemberships.manage(meta, parentName, groupMembershipIndividual, id)
+}
+
+func getDatabricksGroupMemberID(parentName, userName, groupName, servicePrincipalName string) string {
 	if userName != "" {
 		return fmt.Sprintf("user:%s:%s", parentName, userName)
 	}
 
+	if servicePrincipalName != "" {
+		return fmt.Sprintf("service_principal:%s:%s", parentName, servicePrincipalName)
+	}
+
 	return fmt.Sprintf("group:%s:%s", parentName, groupName)
 }
 
+// getPrincipalName returns the member as the Groups API names it. Service
+// principals are members by their application ID, in place of a user name.
 func getPrincipalName(d *schema.ResourceData) groups.PrincipalName {
 	principalName := groups.PrincipalName{}
 
@@ -133,6 +161,10 @@ func getPrincipalName(d *schema.ResourceData) groups.PrincipalName {
 		principalName.UserName = to.StringPtr(v.(string))
 	}
 
+	if v, ok := d.GetOk("service_principal_name"); ok {
+		principalName.UserName = to.StringPtr(v.(string))
+	}
+
 	if v, ok := d.GetOk("group_name"); ok {
 		principalName.GroupName = to.StringPtr(v.(string))
 	}
@@ -150,11 +182,11 @@ func isPrincipalMemberOf(principalName groups.PrincipalName, members *[]groups.P
 	}
 
 	for _, member := range *members {
-		if *principalName.GroupName == *member.GroupName {
+		if principalName.GroupName != nil && member.GroupName != nil && *principalName.GroupName == *member.GroupName {
 			return true
 		}
 
-		if *principalName.UserName == *member.UserName {
+		if principalName.UserName != nil && member.UserName != nil && *principalName.UserName == *member.UserName {
 			return true
 		}
 	}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
@@ -30,6 +31,32 @@ func TestAccDatabricksGroupMember_basic(t *testing.T) {
 	})
 }
 
+func TestIsPrincipalMemberOf(t *testing.T) {
+	members := &[]groups.PrincipalName{
+		{UserName: to.StringPtr("user@example.com")},
+		{GroupName: to.StringPtr("data-engineers")},
+		{UserName: to.StringPtr("00000000-0000-0000-0000-000000000000")},
+	}
+
+	cases := []struct {
+		principalName groups.PrincipalName
+		expected      bool
+	}{
+		{groups.PrincipalName{UserName: to.StringPtr("user@example.com")}, true},
+		{groups.PrincipalName{UserName: to.StringPtr("00000000-0000-0000-0000-000000000000")}, true},
+		{groups.PrincipalName{GroupName: to.StringPtr("data-engineers")}, true},
+		{groups.PrincipalName{GroupName: to.StringPtr("user@example.com")}, false},
+		{groups.PrincipalName{UserName: to.StringPtr("other@example.com")}, false},
+		{groups.PrincipalName{}, false},
+	}
+
+	for _, c := range cases {
+		if actual := isPrincipalMemberOf(c.principalName, members); actual != c.expected {
+			t.Errorf("%#v: expected %t, got %t", c.principalName, c.expected, actual)
+		}
+	}
+}
+
 func testAccCheckDatabricksGroupMemberDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "databricks_group_member" {
@@ -37,24 +64,32 @@ func testAccCheckDatabricksGroupMemberDestroy(s *terraform.State) error {
 		}
 
 		parentName := rs.Primary.Attributes["parent_name"]
-		groupName := rs.Primary.Attributes["group_name"]
-		username := rs.Primary.Attributes["user_name"]
 
-		principalName := groups.PrincipalName{
-			GroupName: &groupName,
-			UserName:  &username,
+		principalName := groups.PrincipalName{}
+		if v := rs.Primary.Attributes["group_name"]; v != "" {
+			principalName.GroupName = &v
+		}
+		if v := rs.Primary.Attributes["user_name"]; v != "" {
+			principalName.UserName = &v
+		}
+		if v := rs.Primary.Attributes["service_principal_name"]; v != "" {
+			principalName.UserName = &v
 		}
 
 		client := testAccProvider.Meta().(*Meta).Groups
 		ctx := testAccProvider.Meta().(*Meta).StopContext
 		resp, err := client.ListMembers(ctx, parentName)
 		if err != nil {
-			if resp.IsHTTPStatus(404) || !isPrincipalMemberOf(principalName, resp.Members) {
+			if resp.IsHTTPStatus(404) {
 				return nil
 			}
 			return err
 		}
 
+		if !isPrincipalMemberOf(principalName, resp.Members) {
+			continue
+		}
+
 		return fmt.Errorf("Databricks group member still exists:\n%#v", resp)
 	}
 
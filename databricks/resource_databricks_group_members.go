@@ -0,0 +1,354 @@
+package databricks
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/innovationnorway/go-databricks/groups"
+)
+
+func resourceDatabricksGroupMembers() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDatabricksGroupMembersCreate,
+		Read:   resourceDatabricksGroupMembersRead,
+		Update: resourceDatabricksGroupMembersUpdate,
+		Delete: resourceDatabricksGroupMembersDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		CustomizeDiff: resourceDatabricksGroupMembersCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"user_names": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Set: schema.HashString,
+			},
+
+			"service_principal_names": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Set: schema.HashString,
+			},
+
+			"group_names": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Set: schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceDatabricksGroupMembersCreate(d *schema.ResourceData, meta interface{}) error {
+	groupName := d.Get("group_name").(string)
+
+	if err := groupMemberships.manage(meta, groupName, groupMembershipAuthoritative, groupName); err != nil {
+		return err
+	}
+
+	if err := applyGroupMembers(d, meta); err != nil {
+		return err
+	}
+
+	d.SetId(groupName)
+
+	return resourceDatabricksGroupMembersRead(d, meta)
+}
+
+func resourceDatabricksGroupMembersRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Groups
+	ctx := meta.(*Meta).StopContext
+
+	groupName := d.Id()
+
+	resp, err := client.ListMembers(ctx, groupName)
+	if err != nil {
+		if resp.IsHTTPStatus(404) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("unable to get group members: %s", err)
+	}
+
+	// The API lists service principals as users, named by application ID.
+	// Users are named by email address, so a member that is not configured
+	// is a service principal if its name is an application ID, e.g. after an
+	// import.
+	userConfigured := d.Get("user_names").(*schema.Set)
+	servicePrincipals := d.Get("service_principal_names").(*schema.Set)
+
+	var userNames, servicePrincipalNames, groupNames []interface{}
+	if resp.Members != nil {
+		for _, member := range *resp.Members {
+			switch {
+			case member.GroupName != nil:
+				groupNames = append(groupNames, *member.GroupName)
+			case member.UserName != nil && servicePrincipals.Contains(*member.UserName):
+				servicePrincipalNames = append(servicePrincipalNames, *member.UserName)
+			case member.UserName != nil && !userConfigured.Contains(*member.UserName) && isApplicationID(*member.UserName):
+				servicePrincipalNames = append(servicePrincipalNames, *member.UserName)
+			case member.UserName != nil:
+				userNames = append(userNames, *member.UserName)
+			}
+		}
+	}
+
+	d.Set("group_name", groupName)
+	d.Set("user_names", schema.NewSet(schema.HashString, userNames))
+	d.Set("service_principal_names", schema.NewSet(schema.HashString, servicePrincipalNames))
+	d.Set("group_names", schema.NewSet(schema.HashString, groupNames))
+
+	return nil
+}
+
+func resourceDatabricksGroupMembersUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := groupMemberships.manage(meta, d.Id(), groupMembershipAuthoritative, d.Id()); err != nil {
+		return err
+	}
+
+	if err := applyGroupMembers(d, meta); err != nil {
+		return err
+	}
+
+	return resourceDatabricksGroupMembersRead(d, meta)
+}
+
+func resourceDatabricksGroupMembersDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Groups
+	ctx := meta.(*Meta).StopContext
+
+	groupName := d.Id()
+
+	for _, member := range expandGroupMembers(d) {
+		attributes := groups.MemberAttributes{
+			ParentName: to.StringPtr(groupName),
+			UserName:   member.UserName,
+			GroupName:  member.GroupName,
+		}
+
+		resp, err := client.RemoveMember(ctx, attributes)
+		if err != nil && !resp.IsHTTPStatus(404) {
+			return fmt.Errorf("unable to remove member %s: %s", describeGroupMember(member), err)
+		}
+	}
+
+	groupMemberships.unregister(meta, groupName, groupMembershipAuthoritative, groupName)
+
+	d.SetId("")
+
+	return nil
+}
+
+func resourceDatabricksGroupMembersCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("group_name") {
+		return nil
+	}
+
+	groupName := d.Get("group_name").(string)
+
+	return groupMemberships.manage(meta, groupName, groupMembershipAuthoritative, groupName)
+}
+
+// applyGroupMembers adds the configured members missing from the group, and
+// removes the members that are not configured.
+func applyGroupMembers(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Groups
+	ctx := meta.(*Meta).StopContext
+
+	groupName := d.Get("group_name").(string)
+
+	resp, err := client.ListMembers(ctx, groupName)
+	if err != nil {
+		return fmt.Errorf("unable to get group members: %s", err)
+	}
+
+	desired := expandGroupMembers(d)
+
+	var adds, removes []groups.PrincipalName
+
+	for _, member := range desired {
+		if !isPrincipalMemberOf(member, resp.Members) {
+			adds = append(adds, member)
+		}
+	}
+
+	if resp.Members != nil {
+		for _, member := range *resp.Members {
+			if !isPrincipalMemberOf(member, &desired) {
+				removes = append(removes, member)
+			}
+		}
+	}
+
+	for _, member := range adds {
+		attributes := groups.MemberAttributes{
+			ParentName: to.StringPtr(groupName),
+			UserName:   member.UserName,
+			GroupName:  member.GroupName,
+		}
+
+		if _, err := client.AddMember(ctx, attributes); err != nil {
+			return fmt.Errorf("unable to add member %s: %s", describeGroupMember(member), err)
+		}
+	}
+
+	for _, member := range removes {
+		attributes := groups.MemberAttributes{
+			ParentName: to.StringPtr(groupName),
+			UserName:   member.UserName,
+			GroupName:  member.GroupName,
+		}
+
+		resp, err := client.RemoveMember(ctx, attributes)
+		if err != nil && !resp.IsHTTPStatus(404) {
+			return fmt.Errorf("unable to remove member %s: %s", describeGroupMember(member), err)
+		}
+	}
+
+	return nil
+}
+
+// expandGroupMembers returns the configured members as the Groups API names
+// them.
+func expandGroupMembers(d *schema.ResourceData) []groups.PrincipalName {
+	var result []groups.PrincipalName
+
+	for _, key := range []string{"user_names", "service_principal_names"} {
+		for _, name := range d.Get(key).(*schema.Set).List() {
+			result = append(result, groups.PrincipalName{UserName: to.StringPtr(name.(string))})
+		}
+	}
+
+	for _, name := range d.Get("group_names").(*schema.Set).List() {
+		result = append(result, groups.PrincipalName{GroupName: to.StringPtr(name.(string))})
+	}
+
+	return result
+}
+
+func describeGroupMember(member groups.PrincipalName) string {
+	if member.GroupName != nil {
+		return fmt.Sprintf("group %q", *member.GroupName)
+	}
+
+	return fmt.Sprintf("user %q", to.String(member.UserName))
+}
+
+type groupMembershipKind string
+
+const (
+	groupMembershipAuthoritative groupMembershipKind = "databricks_group_members"
+	groupMembershipIndividual    groupMembershipKind = "databricks_group_member"
+)
+
+// applicationIDRegexp matches the application ID of a service principal.
+var applicationIDRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func isApplicationID(name string) bool {
+	return applicationIDRegexp.MatchString(name)
+}
+
+// groupMemberships records which groups have their members managed by
+// databricks_group_members and which by databricks_group_member, so the two
+// are not used for the same group. Resources are registered when they are
+// planned, which only happens while they are configured, so replacing one
+// kind with the other is not reported as a conflict. Only resources planned
+// by this provider process are seen, so resources in other configurations
+// are not detected.
+var groupMemberships = &groupMembershipRegistry{
+	groups: make(map[string]map[groupMembershipKind]map[string]bool),
+}
+
+// groupMembershipRegistry holds the IDs of the resources of each kind that
+// manage the members of a group.
+type groupMembershipRegistry struct {
+	mu     sync.Mutex
+	groups map[string]map[groupMembershipKind]map[string]bool
+}
+
+// groupMembershipKey identifies a group across workspaces.
+func groupMembershipKey(meta interface{}, groupName string) string {
+	return fmt.Sprintf("%s|%s", meta.(*Meta).Groups.BaseURI, groupName)
+}
+
+func (r *groupMembershipRegistry) register(meta interface{}, groupName string, kind groupMembershipKind, id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := groupMembershipKey(meta, groupName)
+	if r.groups[key] == nil {
+		r.groups[key] = make(map[groupMembershipKind]map[string]bool)
+	}
+
+	if r.groups[key][kind] == nil {
+		r.groups[key][kind] = make(map[string]bool)
+	}
+
+	r.groups[key][kind][id] = true
+}
+
+// unregister removes a resource that was deleted.
+func (r *groupMembershipRegistry) unregister(meta interface{}, groupName string, kind groupMembershipKind, id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := groupMembershipKey(meta, groupName)
+	delete(r.groups[key][kind], id)
+
+	if len(r.groups[key][kind]) == 0 {
+		delete(r.groups[key], kind)
+	}
+
+	if len(r.groups[key]) == 0 {
+		delete(r.groups, key)
+	}
+}
+
+// manage registers the resource and returns an error if the members of its
+// group are also managed by the other kind of resource.
+func (r *groupMembershipRegistry) manage(meta interface{}, groupName string, kind groupMembershipKind, id string) error {
+	r.register(meta, groupName, kind, id)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var kinds []string
+	for k := range r.groups[groupMembershipKey(meta, groupName)] {
+		kinds = append(kinds, string(k))
+	}
+
+	if len(kinds) < 2 {
+		return nil
+	}
+
+	sort.Strings(kinds)
+
+	return fmt.Errorf("the members of group %q are managed by both %s resources. Use either one databricks_group_members resource, or databricks_group_member resources, for a group", groupName, strings.Join(kinds, " and "))
+}
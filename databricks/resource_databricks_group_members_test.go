@@ -0,0 +1,255 @@
+package databricks
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/innovationnorway/go-databricks/groups"
+)
+
+func TestAccDatabricksGroupMembers_basic(t *testing.T) {
+	resourceName := "databricks_group_members.test"
+	groupName := acctest.RandString(6)
+	memberName := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDatabricksGroupMembersDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabricksGroupMembersBasic(groupName, memberName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "group_name", groupName),
+					resource.TestCheckResourceAttr(resourceName, "user_names.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "group_names.#", "1"),
+				),
+			},
+			{
+				Config: testAccDatabricksGroupMembersUsersOnly(groupName, memberName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "user_names.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "group_names.#", "0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestGroupMembershipRegistry(t *testing.T) {
+	registry := &groupMembershipRegistry{
+		groups: make(map[string]map[groupMembershipKind]map[string]bool),
+	}
+
+	first := &Meta{Groups: groups.NewWithBaseURI("https://first.example.com/api/2.0")}
+	second := &Meta{Groups: groups.NewWithBaseURI("https://second.example.com/api/2.0")}
+
+	if err := registry.manage(first, "example", groupMembershipAuthoritative, "example"); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if err := registry.manage(first, "example", groupMembershipAuthoritative, "example"); err != nil {
+		t.Fatalf("expected no error for the same kind, got %s", err)
+	}
+
+	if err := registry.manage(second, "example", groupMembershipIndividual, "user:example:a"); err != nil {
+		t.Fatalf("expected no error for another workspace, got %s", err)
+	}
+
+	if err := registry.manage(first, "other", groupMembershipIndividual, "user:other:a"); err != nil {
+		t.Fatalf("expected no error for another group, got %s", err)
+	}
+
+	if err := registry.manage(first, "example", groupMembershipIndividual, "user:example:a"); err == nil {
+		t.Fatal("expected an error when both kinds manage the same group")
+	}
+
+	registry.register(second, "example", groupMembershipAuthoritative, "example")
+	if err := registry.manage(second, "example", groupMembershipIndividual, "user:example:b"); err == nil {
+		t.Fatal("expected an error when the other kind was registered first")
+	}
+
+	// Deleting one of several databricks_group_member resources keeps the
+	// others registered.
+	registry.unregister(second, "example", groupMembershipIndividual, "user:example:a")
+	if err := registry.manage(second, "example", groupMembershipAuthoritative, "example"); err == nil {
+		t.Fatal("expected an error while a databricks_group_member resource is registered")
+	}
+
+	// Replacing databricks_group_member resources with a
+	// databricks_group_members resource does not conflict once they are
+	// deleted.
+	registry.unregister(second, "example", groupMembershipIndividual, "user:example:b")
+	if err := registry.manage(second, "example", groupMembershipAuthoritative, "example"); err != nil {
+		t.Fatalf("expected no error once the other kind was unregistered, got %s", err)
+	}
+
+	registry.unregister(first, "other", groupMembershipIndividual, "user:other:a")
+	if _, ok := registry.groups[groupMembershipKey(first, "other")]; ok {
+		t.Error("expected a group without resources to be removed")
+	}
+}
+
+func TestResourceDatabricksGroupMembersRead(t *testing.T) {
+	applicationID := "00000000-1111-2222-3333-444444444444"
+
+	cases := []struct {
+		name                   string
+		config                 map[string]interface{}
+		expectUsers            []string
+		expectServicePrincipal []string
+	}{
+		{
+			name:                   "imported",
+			config:                 map[string]interface{}{"group_name": "example"},
+			expectUsers:            []string{"user@example.com"},
+			expectServicePrincipal: []string{applicationID},
+		},
+		{
+			name: "configured",
+			config: map[string]interface{}{
+				"group_name":              "example",
+				"user_names":              []interface{}{"user@example.com"},
+				"service_principal_names": []interface{}{applicationID},
+			},
+			expectUsers:            []string{"user@example.com"},
+			expectServicePrincipal: []string{applicationID},
+		},
+		{
+			name: "configured as user",
+			config: map[string]interface{}{
+				"group_name": "example",
+				"user_names": []interface{}{"user@example.com", applicationID},
+			},
+			expectUsers: []string{applicationID, "user@example.com"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			api := newTestAPI(map[string]testAPIHandler{
+				"GET /groups/list-members": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{
+						"members": []map[string]interface{}{
+							{"user_name": "user@example.com"},
+							{"user_name": applicationID},
+							{"group_name": "admins"},
+						},
+					}
+				},
+			})
+			meta := testAPIMeta(t, api)
+
+			d := schema.TestResourceDataRaw(t, resourceDatabricksGroupMembers().Schema, c.config)
+			d.SetId("example")
+
+			if err := resourceDatabricksGroupMembersRead(d, meta); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if actual := testSortedStrings(d.Get("user_names").(*schema.Set)); !reflect.DeepEqual(actual, c.expectUsers) {
+				t.Errorf("expected user_names %v, got %v", c.expectUsers, actual)
+			}
+
+			if actual := testSortedStrings(d.Get("service_principal_names").(*schema.Set)); !reflect.DeepEqual(actual, c.expectServicePrincipal) {
+				t.Errorf("expected service_principal_names %v, got %v", c.expectServicePrincipal, actual)
+			}
+
+			if actual := testSortedStrings(d.Get("group_names").(*schema.Set)); !reflect.DeepEqual(actual, []string{"admins"}) {
+				t.Errorf("expected group_names [admins], got %v", actual)
+			}
+		})
+	}
+}
+
+func testSortedStrings(set *schema.Set) []string {
+	var result []string
+	for _, item := range set.List() {
+		result = append(result, item.(string))
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+func testAccCheckDatabricksGroupMembersDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "databricks_group_members" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*Meta).Groups
+		ctx := testAccProvider.Meta().(*Meta).StopContext
+		resp, err := client.ListMembers(ctx, rs.Primary.ID)
+		if err != nil {
+			if resp.IsHTTPStatus(404) {
+				return nil
+			}
+			return err
+		}
+
+		if resp.Members == nil || len(*resp.Members) == 0 {
+			continue
+		}
+
+		return fmt.Errorf("Databricks group members still exist:\n%#v", resp)
+	}
+
+	return nil
+}
+
+func testAccDatabricksGroupMembersBasic(groupName, memberName string) string {
+	return fmt.Sprintf(`
+data "databricks_group_members" "test" {
+  name = "admins"
+}
+
+resource "databricks_group" "test" {
+  display_name = "%s"
+}
+
+resource "databricks_group" "member" {
+  display_name = "%s"
+}
+
+resource "databricks_group_members" "test" {
+  group_name  = databricks_group.test.display_name
+  user_names  = [data.databricks_group_members.test.members[0].user_name]
+  group_names = [databricks_group.member.display_name]
+}
+`, groupName, memberName)
+}
+
+func testAccDatabricksGroupMembersUsersOnly(groupName, memberName string) string {
+	return fmt.Sprintf(`
+data "databricks_group_members" "test" {
+  name = "admins"
+}
+
+resource "databricks_group" "test" {
+  display_name = "%s"
+}
+
+resource "databricks_group" "member" {
+  display_name = "%s"
+}
+
+resource "databricks_group_members" "test" {
+  group_name = databricks_group.test.display_name
+  user_names = [data.databricks_group_members.test.members[0].user_name]
+}
+`, groupName, memberName)
+}
@@ -2,6 +2,8 @@ package databricks
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
@@ -22,12 +24,109 @@ func TestAccDatabricksGroup_basic(t *testing.T) {
 				Config: testAccDatabricksGroupConfig(groupName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "name", groupName),
+					resource.TestCheckResourceAttr(resourceName, "display_name", groupName),
 				),
 			},
 		},
 	})
 }
 
+func TestAccDatabricksGroup_update(t *testing.T) {
+	resourceName := "databricks_group.test"
+	groupName := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDatabricksGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabricksGroupComplete(groupName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "display_name", groupName),
+					resource.TestCheckResourceAttr(resourceName, "allow_cluster_create", "false"),
+				),
+			},
+			{
+				Config: testAccDatabricksGroupComplete(groupName+"-renamed", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "display_name", groupName+"-renamed"),
+					resource.TestCheckResourceAttr(resourceName, "allow_cluster_create", "true"),
+					resource.TestCheckResourceAttr(resourceName, "allow_instance_pool_create", "true"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"name"},
+			},
+		},
+	})
+}
+
+func TestResourceDatabricksGroupStateUpgradeV0(t *testing.T) {
+	cases := []struct {
+		name      string
+		groups    []map[string]interface{}
+		expectID  string
+		expectErr string
+	}{
+		{
+			name: "exact match",
+			groups: []map[string]interface{}{
+				{"id": "1", "displayName": "Data-Engineers"},
+				{"id": "2", "displayName": "data-engineers"},
+			},
+			expectID: "2",
+		},
+		{
+			name:      "missing",
+			groups:    []map[string]interface{}{{"id": "1", "displayName": "Data-Engineers"}},
+			expectErr: `unable to upgrade group "data-engineers", it does not exist`,
+		},
+		{
+			name: "ambiguous",
+			groups: []map[string]interface{}{
+				{"id": "1", "displayName": "data-engineers"},
+				{"id": "2", "displayName": "data-engineers"},
+			},
+			expectErr: "2 groups have that name: 1, 2",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			api := newTestAPI(map[string]testAPIHandler{
+				"GET /preview/scim/v2/Groups": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{"Resources": c.groups}
+				},
+			})
+			meta := testAPIMeta(t, api)
+
+			state, err := resourceDatabricksGroupStateUpgradeV0(map[string]interface{}{
+				"id":   "data-engineers",
+				"name": "data-engineers",
+			}, meta)
+
+			if c.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.expectErr) {
+					t.Fatalf("expected error containing %q, got %v", c.expectErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if state["id"] != c.expectID || state["display_name"] != "data-engineers" {
+				t.Errorf("expected ID %q and display_name %q, got %#v", c.expectID, "data-engineers", state)
+			}
+		})
+	}
+}
+
 func testAccCheckDatabricksGroupDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "databricks_group" {
@@ -36,7 +135,7 @@ func testAccCheckDatabricksGroupDestroy(s *terraform.State) error {
 
 		client := testAccProvider.Meta().(*Meta).Groups
 		ctx := testAccProvider.Meta().(*Meta).StopContext
-		resp, err := client.ListMembers(ctx, rs.Primary.ID)
+		resp, err := getScimGroup(ctx, client, rs.Primary.ID)
 		if err != nil {
 			if resp.IsHTTPStatus(404) {
 				return nil
@@ -58,3 +157,13 @@ resource "databricks_group" "test" {
 }
 `, groupName)
 }
+
+func testAccDatabricksGroupComplete(displayName string, allowCreate bool) string {
+	return fmt.Sprintf(`
+resource "databricks_group" "test" {
+  display_name               = "%s"
+  allow_cluster_create       = %t
+  allow_instance_pool_create = %t
+}
+`, displayName, allowCreate, allowCreate)
+}
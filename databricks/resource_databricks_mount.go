@@ -0,0 +1,487 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/innovationnorway/go-databricks/clusters"
+)
+
+func resourceDatabricksMount() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDatabricksMountCreate,
+		Read:   resourceDatabricksMountRead,
+		Update: resourceDatabricksMountUpdate,
+		Delete: resourceDatabricksMountDelete,
+
+		CustomizeDiff: resourceDatabricksMountCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"mount_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(mountNameRegexp, "must only contain letters, digits, dashes and underscores"),
+			},
+
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"cluster_id", "temporary_cluster"},
+			},
+
+			"temporary_cluster": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"spark_version": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"node_type_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+
+			"abfss": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"storage_account_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"container_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"directory": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"tenant_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"client_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"client_secret_scope": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"client_secret_key": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+				ExactlyOneOf: []string{"abfss", "wasbs", "s3"},
+			},
+
+			"wasbs": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"storage_account_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"container_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"directory": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"account_key_scope": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"account_key_key": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+
+			"s3": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bucket_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"instance_profile_arn": {
+							Type:          schema.TypeString,
+							Optional:      true,
+							ForceNew:      true,
+							ConflictsWith: []string{"cluster_id"},
+						},
+					},
+				},
+			},
+
+			"mount_point": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"source": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDatabricksMountCreate(d *schema.ResourceData, meta interface{}) error {
+	mountPoint := getMountPoint(d.Get("mount_name").(string))
+	config := expandMountConfig(d)
+
+	command := fmt.Sprintf(`configs = {
+%s}
+dbutils.fs.mount(source=%s, mount_point=%s, extra_configs=configs)
+`, config.pythonConfigs(), pythonString(config.Source), pythonString(mountPoint))
+
+	_, err := executeMountCommand(d, meta, command, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return fmt.Errorf("unable to mount %q: %s", mountPoint, err)
+	}
+
+	d.SetId(d.Get("mount_name").(string))
+	d.Set("source", config.Source)
+
+	return resourceDatabricksMountRead(d, meta)
+}
+
+// resourceDatabricksMountRead checks that the mount point still exists in
+// DBFS, which does not need a cluster. When cluster_id is set and the cluster
+// is running, the source is read back from dbutils.fs.mounts(), so a remount
+// to other storage is detected. Otherwise the source recorded when the
+// storage was mounted is kept, rather than starting a cluster on every
+// refresh.
+func resourceDatabricksMountRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Dbfs
+	ctx := meta.(*Meta).StopContext
+
+	mountPoint := getMountPoint(d.Id())
+
+	resp, err := client.GetStatus(ctx, mountPoint)
+	if err != nil {
+		if resp.IsHTTPStatus(404) {
+			log.Printf("[WARN] Mount %q not found, removing from state", mountPoint)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("unable to get mount point status: %s", err)
+	}
+
+	d.Set("mount_name", d.Id())
+	d.Set("mount_point", mountPoint)
+
+	clusterID := d.Get("cluster_id").(string)
+	if clusterID == "" {
+		return nil
+	}
+
+	cluster, err := meta.(*Meta).Clusters.Get(ctx, clusterID)
+	if err != nil {
+		return fmt.Errorf("unable to get cluster: %s", err)
+	}
+
+	if cluster.State != clusters.RUNNING {
+		log.Printf("[DEBUG] Cluster %q is %s, keeping the recorded source of mount %q", clusterID, cluster.State, mountPoint)
+		return nil
+	}
+
+	source, mounted, err := getMountSource(ctx, meta.(*Meta).Clusters, clusterID, mountPoint, d.Timeout(schema.TimeoutRead))
+	if err != nil {
+		return fmt.Errorf("unable to list mounts: %s", err)
+	}
+
+	if !mounted {
+		log.Printf("[WARN] Mount %q not found, removing from state", mountPoint)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("source", source)
+
+	return nil
+}
+
+// resourceDatabricksMountUpdate only handles changes to the cluster the
+// commands run on, which do not affect the mount itself.
+func resourceDatabricksMountUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceDatabricksMountRead(d, meta)
+}
+
+func resourceDatabricksMountDelete(d *schema.ResourceData, meta interface{}) error {
+	mountPoint := getMountPoint(d.Id())
+
+	command := fmt.Sprintf(`if any(m.mountPoint == %[1]s for m in dbutils.fs.mounts()):
+    dbutils.fs.unmount(%[1]s)
+`, pythonString(mountPoint))
+
+	_, err := executeMountCommand(d, meta, command, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return fmt.Errorf("unable to unmount %q: %s", mountPoint, err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// resourceDatabricksMountCustomizeDiff plans to replace the mount when the
+// source read back from the cluster differs from the configured storage,
+// e.g. because it was remounted outside of Terraform.
+func resourceDatabricksMountCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || d.HasChange("abfss") || d.HasChange("wasbs") || d.HasChange("s3") {
+		return nil
+	}
+
+	recorded := d.Get("source").(string)
+	configured := expandMountConfig(d).Source
+
+	if recorded == "" || recorded == configured {
+		return nil
+	}
+
+	if err := d.SetNew("source", configured); err != nil {
+		return err
+	}
+
+	return d.ForceNew("source")
+}
+
+// getMountSource returns the source mounted at mountPoint, and false if
+// nothing is mounted there.
+func getMountSource(ctx context.Context, client clusters.BaseClient, clusterID, mountPoint string, timeout time.Duration) (string, bool, error) {
+	command := fmt.Sprintf(`import json
+print(json.dumps([m.source for m in dbutils.fs.mounts() if m.mountPoint == %s]))
+`, pythonString(mountPoint))
+
+	output, err := executePythonCommand(ctx, client, clusterID, command, timeout)
+	if err != nil {
+		return "", false, err
+	}
+
+	var sources []string
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &sources); err != nil {
+		return "", false, fmt.Errorf("unable to parse mounts %q: %s", output, err)
+	}
+
+	if len(sources) == 0 {
+		return "", false, nil
+	}
+
+	return sources[0], true, nil
+}
+
+// executeMountCommand runs a Python command on the configured cluster, or on
+// a single node cluster that is created for the command and deleted after.
+func executeMountCommand(d *schema.ResourceData, meta interface{}, command string, timeout time.Duration) (string, error) {
+	client := meta.(*Meta).Clusters
+	ctx := meta.(*Meta).StopContext
+
+	clusterID := d.Get("cluster_id").(string)
+
+	if clusterID == "" {
+		attributes := expandMountTemporaryCluster(d)
+
+		resp, err := client.Create(ctx, attributes)
+		if err != nil {
+			return "", fmt.Errorf("unable to create temporary cluster: %s", err)
+		}
+
+		clusterID = to.String(resp.ClusterID)
+
+		// A fresh context is used, so the cluster is also deleted when the
+		// command failed because Terraform is being interrupted.
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			defer cancel()
+
+			if _, err := client.PermanentDelete(ctx, clusters.PermanentDeleteAttributes{ClusterID: &clusterID}); err != nil {
+				log.Printf("[WARN] Unable to delete temporary cluster %q: %s", clusterID, err)
+			}
+		}()
+	}
+
+	if err := waitForClusterRunning(ctx, client, clusterID, timeout); err != nil {
+		return "", err
+	}
+
+	return executePythonCommand(ctx, client, clusterID, command, timeout)
+}
+
+func expandMountTemporaryCluster(d *schema.ResourceData) clusters.Attributes {
+	values := d.Get("temporary_cluster").([]interface{})[0].(map[string]interface{})
+
+	attributes := clusters.Attributes{
+		ClusterName:  to.StringPtr(fmt.Sprintf("terraform-mount-%s", d.Get("mount_name").(string))),
+		SparkVersion: to.StringPtr(values["spark_version"].(string)),
+		NodeTypeID:   to.StringPtr(values["node_type_id"].(string)),
+		NumWorkers:   to.Int32Ptr(0),
+		SparkConf: map[string]*string{
+			"spark.databricks.cluster.profile": to.StringPtr("singleNode"),
+			"spark.master":                     to.StringPtr("local[*]"),
+		},
+		CustomTags: map[string]*string{
+			"ResourceClass": to.StringPtr("SingleNode"),
+		},
+		AutoterminationMinutes: to.Int32Ptr(10),
+	}
+
+	if v, ok := d.GetOk("s3.0.instance_profile_arn"); ok {
+		attributes.AwsAttributes = &clusters.AwsAttributes{
+			InstanceProfileArn: to.StringPtr(v.(string)),
+		}
+	}
+
+	return attributes
+}
+
+var mountNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func getMountPoint(name string) string {
+	return fmt.Sprintf("/mnt/%s", name)
+}
+
+// mountConfig is the source of a mount and its extra configs. The values of
+// Configs are Python expressions, so secrets are only resolved on the cluster.
+type mountConfig struct {
+	Source  string
+	Configs map[string]string
+}
+
+func (c mountConfig) pythonConfigs() string {
+	keys := make([]string, 0, len(c.Configs))
+	for k := range c.Configs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, "    %s: %s,\n", pythonString(k), c.Configs[k])
+	}
+
+	return b.String()
+}
+
+// mountConfigGetter is implemented by both schema.ResourceData and
+// schema.ResourceDiff.
+type mountConfigGetter interface {
+	Get(key string) interface{}
+	GetOk(key string) (interface{}, bool)
+}
+
+func expandMountConfig(d mountConfigGetter) mountConfig {
+	if v, ok := d.GetOk("abfss"); ok {
+		values := v.([]interface{})[0].(map[string]interface{})
+		account := values["storage_account_name"].(string)
+
+		return mountConfig{
+			Source: fmt.Sprintf("abfss://%s@%s.dfs.core.windows.net/%s", values["container_name"].(string), account, strings.TrimPrefix(values["directory"].(string), "/")),
+			Configs: map[string]string{
+				"fs.azure.account.auth.type":              pythonString("OAuth"),
+				"fs.azure.account.oauth.provider.type":    pythonString("org.apache.hadoop.fs.azurebfs.oauth2.ClientCredsTokenProvider"),
+				"fs.azure.account.oauth2.client.id":       pythonString(values["client_id"].(string)),
+				"fs.azure.account.oauth2.client.secret":   pythonSecret(values["client_secret_scope"].(string), values["client_secret_key"].(string)),
+				"fs.azure.account.oauth2.client.endpoint": pythonString(fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/token", values["tenant_id"].(string))),
+			},
+		}
+	}
+
+	if v, ok := d.GetOk("wasbs"); ok {
+		values := v.([]interface{})[0].(map[string]interface{})
+		account := values["storage_account_name"].(string)
+
+		return mountConfig{
+			Source: fmt.Sprintf("wasbs://%s@%s.blob.core.windows.net/%s", values["container_name"].(string), account, strings.TrimPrefix(values["directory"].(string), "/")),
+			Configs: map[string]string{
+				fmt.Sprintf("fs.azure.account.key.%s.blob.core.windows.net", account): pythonSecret(values["account_key_scope"].(string), values["account_key_key"].(string)),
+			},
+		}
+	}
+
+	values := d.Get("s3").([]interface{})[0].(map[string]interface{})
+
+	return mountConfig{
+		Source:  fmt.Sprintf("s3a://%s", values["bucket_name"].(string)),
+		Configs: map[string]string{},
+	}
+}
+
+// pythonString quotes s as a Python string literal.
+func pythonString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+func pythonSecret(scope, key string) string {
+	return fmt.Sprintf("dbutils.secrets.get(scope=%s, key=%s)", pythonString(scope), pythonString(key))
+}
@@ -0,0 +1,279 @@
+package databricks
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestExpandMountConfig(t *testing.T) {
+	cases := []struct {
+		name     string
+		raw      map[string]interface{}
+		expected mountConfig
+	}{
+		{
+			name: "abfss",
+			raw: map[string]interface{}{
+				"mount_name": "landing",
+				"cluster_id": "abc",
+				"abfss": []interface{}{map[string]interface{}{
+					"storage_account_name": "account",
+					"container_name":       "container",
+					"directory":            "/raw",
+					"tenant_id":            "tenant",
+					"client_id":            "client",
+					"client_secret_scope":  "scope",
+					"client_secret_key":    "key",
+				}},
+			},
+			expected: mountConfig{
+				Source: "abfss://container@account.dfs.core.windows.net/raw",
+				Configs: map[string]string{
+					"fs.azure.account.auth.type":              `"OAuth"`,
+					"fs.azure.account.oauth.provider.type":    `"org.apache.hadoop.fs.azurebfs.oauth2.ClientCredsTokenProvider"`,
+					"fs.azure.account.oauth2.client.id":       `"client"`,
+					"fs.azure.account.oauth2.client.secret":   `dbutils.secrets.get(scope="scope", key="key")`,
+					"fs.azure.account.oauth2.client.endpoint": `"https://login.microsoftonline.com/tenant/oauth2/token"`,
+				},
+			},
+		},
+		{
+			name: "wasbs",
+			raw: map[string]interface{}{
+				"mount_name": "landing",
+				"cluster_id": "abc",
+				"wasbs": []interface{}{map[string]interface{}{
+					"storage_account_name": "account",
+					"container_name":       "container",
+					"account_key_scope":    "scope",
+					"account_key_key":      "key",
+				}},
+			},
+			expected: mountConfig{
+				Source: "wasbs://container@account.blob.core.windows.net/",
+				Configs: map[string]string{
+					"fs.azure.account.key.account.blob.core.windows.net": `dbutils.secrets.get(scope="scope", key="key")`,
+				},
+			},
+		},
+		{
+			name: "s3",
+			raw: map[string]interface{}{
+				"mount_name": "landing",
+				"cluster_id": "abc",
+				"s3": []interface{}{map[string]interface{}{
+					"bucket_name": "bucket",
+				}},
+			},
+			expected: mountConfig{
+				Source:  "s3a://bucket",
+				Configs: map[string]string{},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceDatabricksMount().Schema, c.raw)
+
+			if actual := expandMountConfig(d); !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected:\n%#v\ngot:\n%#v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMountConfigPythonConfigs(t *testing.T) {
+	config := mountConfig{
+		Configs: map[string]string{
+			"b.key":       pythonSecret("scope", "key"),
+			"a.key":       pythonString(`quoted "value"`),
+			"c.key\nnext": pythonString("value"),
+		},
+	}
+
+	expected := `    "a.key": "quoted \"value\"",
+    "b.key": dbutils.secrets.get(scope="scope", key="key"),
+    "c.key\nnext": "value",
+`
+
+	if actual := config.pythonConfigs(); actual != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+
+	if actual := (mountConfig{}).pythonConfigs(); actual != "" {
+		t.Errorf("expected no configs, got %q", actual)
+	}
+}
+
+func TestResourceDatabricksMountRead(t *testing.T) {
+	cases := []struct {
+		name         string
+		clusterID    string
+		mounted      bool
+		clusterState string
+		mounts       string
+		expectID     string
+		expectSource string
+		expectCalls  []string
+	}{
+		{
+			name:         "unmounted",
+			clusterID:    "abc",
+			expectID:     "",
+			expectSource: "s3a://bucket",
+			expectCalls:  []string{"GET /dbfs/get-status"},
+		},
+		{
+			name:         "temporary cluster",
+			mounted:      true,
+			expectID:     "landing",
+			expectSource: "s3a://bucket",
+			expectCalls:  []string{"GET /dbfs/get-status"},
+		},
+		{
+			name:         "cluster terminated",
+			clusterID:    "abc",
+			mounted:      true,
+			clusterState: "TERMINATED",
+			expectID:     "landing",
+			expectSource: "s3a://bucket",
+			expectCalls:  []string{"GET /dbfs/get-status", "GET /clusters/get"},
+		},
+		{
+			name:         "remounted",
+			clusterID:    "abc",
+			mounted:      true,
+			clusterState: "RUNNING",
+			mounts:       `["s3a://other"]`,
+			expectID:     "landing",
+			expectSource: "s3a://other",
+			expectCalls:  []string{"GET /dbfs/get-status", "GET /clusters/get", "POST /api/1.2/contexts/create", "POST /api/1.2/commands/execute", "GET /api/1.2/commands/status", "POST /api/1.2/contexts/destroy"},
+		},
+		{
+			name:         "not in mounts",
+			clusterID:    "abc",
+			mounted:      true,
+			clusterState: "RUNNING",
+			mounts:       `[]`,
+			expectID:     "",
+			expectSource: "s3a://bucket",
+			expectCalls:  []string{"GET /dbfs/get-status", "GET /clusters/get", "POST /api/1.2/contexts/create", "POST /api/1.2/commands/execute", "GET /api/1.2/commands/status", "POST /api/1.2/contexts/destroy"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			handlers := map[string]testAPIHandler{
+				"GET /clusters/get": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{"cluster_id": "abc", "state": c.clusterState}
+				},
+				"POST /api/1.2/contexts/create": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{"id": "context"}
+				},
+				"POST /api/1.2/commands/execute": func(req testAPIRequest) (int, interface{}) {
+					if command := req.Body["command"].(string); !strings.Contains(command, `m.mountPoint == "/mnt/landing"`) {
+						t.Errorf("expected the mounts of /mnt/landing to be listed, got %q", command)
+					}
+					return http.StatusOK, map[string]interface{}{"id": "command"}
+				},
+				"GET /api/1.2/commands/status": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{
+						"id":      "command",
+						"status":  "Finished",
+						"results": map[string]interface{}{"resultType": "text", "data": c.mounts + "\n"},
+					}
+				},
+				"POST /api/1.2/contexts/destroy": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{}
+				},
+			}
+			if c.mounted {
+				handlers["GET /dbfs/get-status"] = func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{"path": req.Query.Get("path"), "is_dir": true}
+				}
+			}
+
+			api := newTestAPI(handlers)
+			meta := testAPIMeta(t, api)
+
+			raw := map[string]interface{}{
+				"mount_name": "landing",
+				"s3":         []interface{}{map[string]interface{}{"bucket_name": "bucket"}},
+			}
+			if c.clusterID != "" {
+				raw["cluster_id"] = c.clusterID
+			} else {
+				raw["temporary_cluster"] = []interface{}{map[string]interface{}{"spark_version": "6.4.x-scala2.11", "node_type_id": "i3.xlarge"}}
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceDatabricksMount().Schema, raw)
+			d.SetId("landing")
+			d.Set("source", "s3a://bucket")
+
+			if err := resourceDatabricksMountRead(d, meta); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if d.Id() != c.expectID {
+				t.Errorf("expected ID %q, got %q", c.expectID, d.Id())
+			}
+
+			if calls := api.calls(); !reflect.DeepEqual(calls, c.expectCalls) {
+				t.Errorf("expected calls %v, got %v", c.expectCalls, calls)
+			}
+
+			if actual := d.Get("source").(string); actual != c.expectSource {
+				t.Errorf("expected source %q, got %q", c.expectSource, actual)
+			}
+		})
+	}
+}
+
+func TestResourceDatabricksMountCustomizeDiff(t *testing.T) {
+	cases := []struct {
+		name          string
+		source        string
+		expectReplace bool
+	}{
+		{"unchanged", "s3a://bucket", false},
+		{"remounted", "s3a://other", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID: "landing",
+				Attributes: map[string]string{
+					"id":               "landing",
+					"mount_name":       "landing",
+					"cluster_id":       "abc",
+					"s3.#":             "1",
+					"s3.0.bucket_name": "bucket",
+					"mount_point":      "/mnt/landing",
+					"source":           c.source,
+				},
+			}
+
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"mount_name": "landing",
+				"cluster_id": "abc",
+				"s3":         []interface{}{map[string]interface{}{"bucket_name": "bucket"}},
+			})
+
+			diff, err := resourceDatabricksMount().Diff(state, config, nil)
+			if err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if replace := diff != nil && diff.RequiresNew(); replace != c.expectReplace {
+				t.Errorf("expected replacement %t, got %t (%#v)", c.expectReplace, replace, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,336 @@
+package databricks
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	pathpkg "path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/innovationnorway/go-databricks/workspace"
+)
+
+func resourceDatabricksNotebook() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDatabricksNotebookCreate,
+		Read:   resourceDatabricksNotebookRead,
+		Update: resourceDatabricksNotebookUpdate,
+		Delete: resourceDatabricksNotebookDelete,
+
+		CustomizeDiff: resourceDatabricksNotebookCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"source": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"format": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"language": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"content_sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"export_sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"object_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDatabricksNotebookCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	path := d.Get("path").(string)
+
+	parent := pathpkg.Dir(path)
+	if _, err := client.Mkdirs(ctx, workspace.MkdirsAttributes{Path: to.StringPtr(parent)}); err != nil {
+		return fmt.Errorf("unable to create directory %q: %s", parent, err)
+	}
+
+	if err := importNotebookFromSource(ctx, client, path, d.Get("source").(string), false); err != nil {
+		return err
+	}
+
+	d.SetId(path)
+
+	if err := setNotebookExportHash(d, meta); err != nil {
+		return err
+	}
+
+	return resourceDatabricksNotebookRead(d, meta)
+}
+
+func resourceDatabricksNotebookRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	resp, err := client.GetStatus(ctx, d.Id())
+	if err != nil {
+		if resp.IsHTTPStatus(404) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("unable to get object status: %s", err)
+	}
+
+	d.Set("path", resp.Path)
+	d.Set("language", resp.Language)
+
+	if err := d.Set("object_id", strconv.FormatInt(to.Int64(resp.ObjectID), 10)); err != nil {
+		return err
+	}
+
+	format, _, err := notebookSourceFormat(d.Get("source").(string))
+	if err != nil {
+		return err
+	}
+
+	d.Set("format", format)
+
+	content, export, err := refreshNotebookHashes(ctx, client, d.Id(), format, d.Get("content_sha256").(string), d.Get("export_sha256").(string))
+	if err != nil {
+		return err
+	}
+
+	d.Set("content_sha256", content)
+	d.Set("export_sha256", export)
+
+	return nil
+}
+
+func resourceDatabricksNotebookUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	if err := importNotebookFromSource(ctx, client, d.Id(), d.Get("source").(string), true); err != nil {
+		return err
+	}
+
+	if err := setNotebookExportHash(d, meta); err != nil {
+		return err
+	}
+
+	return resourceDatabricksNotebookRead(d, meta)
+}
+
+func resourceDatabricksNotebookDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	attributes := workspace.DeleteAttributes{
+		Path: to.StringPtr(d.Id()),
+	}
+
+	resp, err := client.Delete(ctx, attributes)
+	if err != nil {
+		if resp.IsHTTPStatus(404) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("unable to delete object: %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// resourceDatabricksNotebookCustomizeDiff hashes the local source file. When
+// it no longer matches the hash of the notebook exported during refresh, the
+// notebook is imported again.
+func resourceDatabricksNotebookCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("source") {
+		return nil
+	}
+
+	source := d.Get("source").(string)
+
+	format, language, err := notebookSourceFormat(source)
+	if err != nil {
+		return err
+	}
+
+	if d.Get("format").(string) != string(format) {
+		if err := d.SetNew("format", string(format)); err != nil {
+			return err
+		}
+	}
+
+	if language != "" && d.Get("language").(string) != string(language) {
+		if err := d.SetNew("language", string(language)); err != nil {
+			return err
+		}
+	}
+
+	content, err := ioutil.ReadFile(source)
+	if err != nil {
+		return fmt.Errorf("unable to read source: %s", err)
+	}
+
+	hash := workspaceContentHash(content)
+	if d.Get("content_sha256").(string) == hex.EncodeToString(hash[:]) {
+		return nil
+	}
+
+	return d.SetNew("content_sha256", hex.EncodeToString(hash[:]))
+}
+
+// setNotebookExportHash records the checksum of the SOURCE export of a
+// notebook that was just imported in a format other than SOURCE, so that
+// changes made outside of Terraform can be detected on refresh.
+func setNotebookExportHash(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	format, _, err := notebookSourceFormat(d.Get("source").(string))
+	if err != nil {
+		return err
+	}
+
+	if format == workspace.DBC || workspaceContentComparable(string(format)) {
+		return nil
+	}
+
+	hash, err := exportNotebookHash(ctx, client, d.Id(), workspace.SOURCE)
+	if err != nil {
+		return err
+	}
+
+	return d.Set("export_sha256", hash)
+}
+
+// refreshNotebookHashes returns the content and SOURCE export checksums to
+// record for a notebook imported in format, given the ones in state.
+func refreshNotebookHashes(ctx context.Context, client workspace.BaseClient, path string, format workspace.Format, content, export string) (string, string, error) {
+	// DBC archives are not reproducible, so their content is not compared.
+	if format == workspace.DBC && content != "" {
+		return content, "", nil
+	}
+
+	exportFormat := workspace.SOURCE
+	if format == workspace.DBC {
+		exportFormat = workspace.DBC
+	}
+
+	hash, err := exportNotebookHash(ctx, client, path, exportFormat)
+	if err != nil {
+		return "", "", err
+	}
+
+	if format == workspace.DBC || workspaceContentComparable(string(format)) {
+		return hash, "", nil
+	}
+
+	// HTML and JUPYTER exports never match the imported file. The SOURCE
+	// export is compared with the one made right after the last import
+	// instead, and any difference is planned as a change of content.
+	switch {
+	case export == "" && content == "":
+		return hash, hash, nil
+	case export == "":
+		return content, hash, nil
+	case export == hash && content != "":
+		return content, export, nil
+	default:
+		return hash, export, nil
+	}
+}
+
+// notebookSourceFormat infers the import format of a local file from its
+// extension, and for source files also the language.
+func notebookSourceFormat(source string) (workspace.Format, workspace.Language, error) {
+	switch ext := strings.ToLower(filepath.Ext(source)); ext {
+	case ".py":
+		return workspace.SOURCE, workspace.PYTHON, nil
+	case ".scala":
+		return workspace.SOURCE, workspace.SCALA, nil
+	case ".sql":
+		return workspace.SOURCE, workspace.SQL, nil
+	case ".r":
+		return workspace.SOURCE, workspace.R, nil
+	case ".ipynb":
+		return workspace.JUPYTER, "", nil
+	case ".dbc":
+		return workspace.DBC, "", nil
+	case ".html":
+		return workspace.HTML, "", nil
+	default:
+		return "", "", fmt.Errorf("unable to infer the notebook format of %q: unsupported extension %q", source, ext)
+	}
+}
+
+func importNotebookFromSource(ctx context.Context, client workspace.BaseClient, path, source string, overwrite bool) error {
+	format, language, err := notebookSourceFormat(source)
+	if err != nil {
+		return err
+	}
+
+	content, err := ioutil.ReadFile(source)
+	if err != nil {
+		return fmt.Errorf("unable to read source: %s", err)
+	}
+
+	attributes := workspace.ImportAttributes{
+		Path:      to.StringPtr(path),
+		Format:    format,
+		Language:  language,
+		Content:   to.StringPtr(base64.StdEncoding.EncodeToString(content)),
+		Overwrite: to.BoolPtr(overwrite),
+	}
+
+	if _, err := client.Import(ctx, attributes); err != nil {
+		return fmt.Errorf("unable to import notebook: %s", err)
+	}
+
+	return nil
+}
+
+// exportNotebookHash returns the SHA-256 checksum of the normalised content of
+// a notebook exported in format.
+func exportNotebookHash(ctx context.Context, client workspace.BaseClient, path string, format workspace.Format) (string, error) {
+	resp, err := client.Export(ctx, path, string(format), nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to export object: %s", err)
+	}
+
+	content, err := base64.StdEncoding.DecodeString(to.String(resp.Content))
+	if err != nil {
+		return "", fmt.Errorf("unable to decode exported content: %s", err)
+	}
+
+	hash := workspaceContentHash(content)
+
+	return hex.EncodeToString(hash[:]), nil
+}
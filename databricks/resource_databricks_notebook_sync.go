@@ -0,0 +1,560 @@
+package databricks
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/innovationnorway/go-databricks/workspace"
+)
+
+func resourceDatabricksNotebookSync() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDatabricksNotebookSyncCreate,
+		Read:   resourceDatabricksNotebookSyncRead,
+		Update: resourceDatabricksNotebookSyncUpdate,
+		Delete: resourceDatabricksNotebookSyncDelete,
+
+		CustomizeDiff: resourceDatabricksNotebookSyncCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"source": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"include": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateGlob,
+				},
+			},
+
+			"exclude": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateGlob,
+				},
+			},
+
+			"parallelism": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      4,
+				ValidateFunc: validation.IntBetween(1, 32),
+			},
+
+			"files": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"exports": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"directories": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceDatabricksNotebookSyncCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	root := d.Get("path").(string)
+	source := d.Get("source").(string)
+
+	local, err := listLocalNotebooks(source, expandSyncGlobs(d))
+	if err != nil {
+		return err
+	}
+
+	result, err := syncNotebooks(ctx, client, root, source, map[string]interface{}{}, local, d.Get("parallelism").(int))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(root)
+	d.Set("exports", result.Exports)
+	d.Set("directories", result.Directories)
+
+	return resourceDatabricksNotebookSyncRead(d, meta)
+}
+
+func resourceDatabricksNotebookSyncRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	root := d.Id()
+	globs := expandSyncGlobs(d)
+	state := d.Get("files").(map[string]interface{})
+	exports := d.Get("exports").(map[string]interface{})
+
+	// Notebooks have no extension in the workspace. Names already in the
+	// state keep their extension, so the notebook is exported in the format
+	// it was imported in. Other notebooks are named after their language.
+	known := make(map[string]string, len(state))
+	for name := range state {
+		known[trimNotebookExt(name)] = name
+	}
+
+	var names []string
+	err := walkWorkspace(ctx, client, root, func(info workspace.ObjectInfo) error {
+		if info.ObjectType != workspace.NOTEBOOK {
+			return nil
+		}
+
+		name := strings.TrimPrefix(strings.TrimPrefix(to.String(info.Path), root), "/")
+		if v, ok := known[name]; ok {
+			name = v
+		} else {
+			name += notebookLanguageExt(to.String(info.Language))
+		}
+
+		if globs.match(name) {
+			names = append(names, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	files := make(map[string]interface{}, len(names))
+	newExports := make(map[string]interface{})
+
+	err = runParallel(d.Get("parallelism").(int), names, func(name string) error {
+		format, _, err := notebookSourceFormat(name)
+		if err != nil {
+			return err
+		}
+
+		hash, _ := state[name].(string)
+		recorded, _ := exports[name].(string)
+
+		content, export, err := refreshNotebookHashes(ctx, client, path.Join(root, trimNotebookExt(name)), format, hash, recorded)
+		if err != nil {
+			return fmt.Errorf("%s: %s", name, err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		files[name] = content
+		if export != "" {
+			newExports[name] = export
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.Set("path", root)
+	d.Set("files", files)
+	d.Set("exports", newExports)
+
+	return nil
+}
+
+func resourceDatabricksNotebookSyncUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	o, n := d.GetChange("files")
+
+	result, err := syncNotebooks(ctx, client, d.Id(), d.Get("source").(string), o.(map[string]interface{}), n.(map[string]interface{}), d.Get("parallelism").(int))
+
+	// The exports and directories of the notebooks that were synced are
+	// recorded even if others failed.
+	exports := d.Get("exports").(map[string]interface{})
+	for name, hash := range result.Exports {
+		exports[name] = hash
+	}
+	d.Set("exports", exports)
+
+	directories := d.Get("directories").(*schema.Set)
+	for _, dir := range result.Directories {
+		directories.Add(dir)
+	}
+	d.Set("directories", directories)
+
+	if err != nil {
+		return err
+	}
+
+	return resourceDatabricksNotebookSyncRead(d, meta)
+}
+
+func resourceDatabricksNotebookSyncDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	_, err := syncNotebooks(ctx, client, d.Id(), d.Get("source").(string), d.Get("files").(map[string]interface{}), map[string]interface{}{}, d.Get("parallelism").(int))
+	if err != nil {
+		return err
+	}
+
+	if err := deleteEmptyWorkspaceDirectories(ctx, client, expandStringList(d.Get("directories").(*schema.Set).List())); err != nil {
+		return err
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// resourceDatabricksNotebookSyncCustomizeDiff plans the checksums of the local
+// notebooks as the new value of files. Any difference from the checksums of
+// the exported notebooks recorded during refresh is synced on apply.
+func resourceDatabricksNotebookSyncCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("source") || !d.NewValueKnown("include") || !d.NewValueKnown("exclude") {
+		return d.SetNewComputed("files")
+	}
+
+	globs := syncGlobs{
+		Include: expandStringList(d.Get("include").([]interface{})),
+		Exclude: expandStringList(d.Get("exclude").([]interface{})),
+	}
+
+	local, err := listLocalNotebooks(d.Get("source").(string), globs)
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(local, d.Get("files").(map[string]interface{})) {
+		return nil
+	}
+
+	return d.SetNew("files", local)
+}
+
+// notebookSyncResult describes the changes made by syncNotebooks.
+type notebookSyncResult struct {
+	// Exports are the checksums of the SOURCE export of the imported
+	// notebooks whose format is not compared directly.
+	Exports map[string]interface{}
+
+	// Directories are the directories that were created.
+	Directories []interface{}
+}
+
+// syncNotebooks imports every notebook whose checksum differs between remote
+// and local, and deletes remote notebooks that are not in local. Both maps
+// are keyed by the path of the local file relative to root. It does not stop
+// at the first failure, all errors are returned together.
+func syncNotebooks(ctx context.Context, client workspace.BaseClient, root, source string, remote, local map[string]interface{}, parallelism int) (notebookSyncResult, error) {
+	result := notebookSyncResult{
+		Exports: make(map[string]interface{}),
+	}
+
+	var imports, deletes []string
+	dirs := make(map[string]bool)
+	keep := make(map[string]bool, len(local))
+
+	for name, hash := range local {
+		keep[trimNotebookExt(name)] = true
+
+		if v, ok := remote[name]; ok && v.(string) == hash.(string) {
+			continue
+		}
+		imports = append(imports, name)
+		dirs[path.Dir(path.Join(root, name))] = true
+	}
+
+	// A notebook whose local file changed extension is overwritten by the
+	// import, rather than deleted.
+	for name := range remote {
+		if !keep[trimNotebookExt(name)] {
+			deletes = append(deletes, name)
+		}
+	}
+
+	var errs *multierror.Error
+
+	failed := make(map[string]bool)
+	existing := make(map[string]bool)
+
+	for _, dir := range sortedKeys(dirs) {
+		created, err := mkdirsWorkspace(ctx, client, dir, existing)
+		for _, c := range created {
+			result.Directories = append(result.Directories, c)
+		}
+		if err != nil {
+			failed[dir] = true
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	var mu sync.Mutex
+
+	err := runParallel(parallelism, imports, func(name string) error {
+		notebookPath := path.Join(root, trimNotebookExt(name))
+		if failed[path.Dir(notebookPath)] {
+			return nil
+		}
+
+		err := importNotebookFromSource(ctx, client, notebookPath, filepath.Join(source, filepath.FromSlash(name)), true)
+		if err != nil {
+			return fmt.Errorf("%s: %s", name, err)
+		}
+
+		format, _, err := notebookSourceFormat(name)
+		if err != nil || format == workspace.DBC || workspaceContentComparable(string(format)) {
+			return err
+		}
+
+		hash, err := exportNotebookHash(ctx, client, notebookPath, workspace.SOURCE)
+		if err != nil {
+			return fmt.Errorf("%s: %s", name, err)
+		}
+
+		mu.Lock()
+		result.Exports[name] = hash
+		mu.Unlock()
+
+		return nil
+	})
+	errs = multierror.Append(errs, err)
+
+	err = runParallel(parallelism, deletes, func(name string) error {
+		resp, err := client.Delete(ctx, workspace.DeleteAttributes{Path: to.StringPtr(path.Join(root, trimNotebookExt(name)))})
+		if err != nil && !resp.IsHTTPStatus(404) {
+			return fmt.Errorf("%s: unable to delete notebook: %s", name, err)
+		}
+		return nil
+	})
+	errs = multierror.Append(errs, err)
+
+	return result, errs.ErrorOrNil()
+}
+
+// mkdirsWorkspace creates dir with its missing parents, and returns the
+// directories that did not exist before. Directories known to exist are
+// recorded in existing, so they are not looked up again.
+func mkdirsWorkspace(ctx context.Context, client workspace.BaseClient, dir string, existing map[string]bool) ([]string, error) {
+	var created []string
+
+	for p := dir; !existing[p]; p = path.Dir(p) {
+		resp, err := client.GetStatus(ctx, p)
+		if err == nil {
+			existing[p] = true
+			break
+		}
+		if !resp.IsHTTPStatus(404) {
+			return nil, fmt.Errorf("unable to get status of directory %q: %s", p, err)
+		}
+
+		created = append(created, p)
+		if p == "/" {
+			break
+		}
+	}
+
+	if len(created) == 0 {
+		return nil, nil
+	}
+
+	if _, err := client.Mkdirs(ctx, workspace.MkdirsAttributes{Path: to.StringPtr(dir)}); err != nil {
+		return nil, fmt.Errorf("unable to create directory %q: %s", dir, err)
+	}
+
+	for _, p := range created {
+		existing[p] = true
+	}
+
+	return created, nil
+}
+
+// deleteEmptyWorkspaceDirectories deletes the given directories, deepest
+// first. Directories that are not empty, because they hold objects that
+// were not created by Terraform, are left alone.
+func deleteEmptyWorkspaceDirectories(ctx context.Context, client workspace.BaseClient, dirs []string) error {
+	sort.SliceStable(dirs, func(i, j int) bool {
+		return strings.Count(dirs[i], "/") > strings.Count(dirs[j], "/")
+	})
+
+	var errs *multierror.Error
+
+	for _, dir := range dirs {
+		resp, err := client.List(ctx, dir)
+		if err != nil {
+			if resp.IsHTTPStatus(404) {
+				continue
+			}
+			errs = multierror.Append(errs, fmt.Errorf("unable to list directory %q: %s", dir, err))
+			continue
+		}
+
+		if resp.Objects != nil && len(*resp.Objects) > 0 {
+			log.Printf("[DEBUG] Directory %q is not empty, leaving it in place", dir)
+			continue
+		}
+
+		attributes := workspace.DeleteAttributes{
+			Path:      to.StringPtr(dir),
+			Recursive: to.BoolPtr(false),
+		}
+
+		if resp, err := client.Delete(ctx, attributes); err != nil && !resp.IsHTTPStatus(404) {
+			errs = multierror.Append(errs, fmt.Errorf("unable to delete directory %q: %s", dir, err))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func sortedKeys(m map[string]bool) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// walkWorkspace calls fn for every object below root, including directories.
+func walkWorkspace(ctx context.Context, client workspace.BaseClient, root string, fn func(workspace.ObjectInfo) error) error {
+	resp, err := client.List(ctx, root)
+	if err != nil {
+		if resp.IsHTTPStatus(404) {
+			return nil
+		}
+		return fmt.Errorf("unable to list %q: %s", root, err)
+	}
+
+	if resp.Objects == nil {
+		return nil
+	}
+
+	for _, info := range *resp.Objects {
+		if err := fn(info); err != nil {
+			return err
+		}
+
+		if info.ObjectType == workspace.DIRECTORY {
+			if err := walkWorkspace(ctx, client, to.String(info.Path), fn); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// listLocalNotebooks returns the checksums of the normalised content of the
+// notebooks below source selected by globs, keyed by their slash separated
+// relative path. Files with an extension that is not a notebook format are
+// skipped.
+func listLocalNotebooks(source string, globs syncGlobs) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	notebooks := make(map[string]string)
+
+	err := filepath.Walk(source, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		if _, _, err := notebookSourceFormat(p); err != nil {
+			return nil
+		}
+
+		rel, err := filepath.Rel(source, p)
+		if err != nil {
+			return err
+		}
+
+		name := filepath.ToSlash(rel)
+		if !globs.match(name) {
+			return nil
+		}
+
+		// Files that only differ in extension would be imported as the same
+		// notebook.
+		notebook := trimNotebookExt(name)
+		if other, ok := notebooks[notebook]; ok {
+			return fmt.Errorf("%q and %q would both be imported as the notebook %q", other, name, notebook)
+		}
+		notebooks[notebook] = name
+
+		content, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		hash := workspaceContentHash(content)
+		result[name] = hex.EncodeToString(hash[:])
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read source directory: %s", err)
+	}
+
+	return result, nil
+}
+
+func trimNotebookExt(name string) string {
+	return strings.TrimSuffix(name, path.Ext(name))
+}
+
+// notebookLanguageExt returns the extension of a source file in language.
+func notebookLanguageExt(language string) string {
+	switch workspace.Language(language) {
+	case workspace.SCALA:
+		return ".scala"
+	case workspace.SQL:
+		return ".sql"
+	case workspace.R:
+		return ".r"
+	default:
+		return ".py"
+	}
+}
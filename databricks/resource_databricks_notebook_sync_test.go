@@ -0,0 +1,283 @@
+package databricks
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/innovationnorway/go-databricks/workspace"
+)
+
+func TestAccDatabricksNotebookSync_basic(t *testing.T) {
+	resourceName := "databricks_notebook_sync.test"
+	path := fmt.Sprintf("/Shared/%s", acctest.RandString(6))
+
+	source, err := ioutil.TempDir("", "notebook-sync")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(source)
+
+	for name, content := range map[string]string{
+		"etl/load.py":       "print(\"load\")",
+		"reports/daily.sql": "SELECT 1",
+		"README.md":         "Not a notebook",
+	} {
+		p := filepath.Join(source, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDatabricksNotebookSyncDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabricksNotebookSyncBasic(path, source),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "path", path),
+					resource.TestCheckResourceAttr(resourceName, "files.%", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "files.etl/load.py"),
+					resource.TestCheckResourceAttrSet(resourceName, "files.reports/daily.sql"),
+				),
+			},
+		},
+	})
+}
+
+func TestListLocalNotebooks_collision(t *testing.T) {
+	source, err := ioutil.TempDir("", "notebook-sync")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(source)
+
+	for _, name := range []string{"a.py", "a.sql", "b.py"} {
+		if err := ioutil.WriteFile(filepath.Join(source, name), []byte("1"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := listLocalNotebooks(source, syncGlobs{}); err == nil {
+		t.Fatal("expected an error for files imported as the same notebook")
+	}
+
+	files, err := listLocalNotebooks(source, syncGlobs{Exclude: []string{"*.sql"}})
+	if err != nil {
+		t.Fatalf("expected no error when one of them is excluded, got %s", err)
+	}
+
+	if len(files) != 2 {
+		t.Errorf("expected 2 notebooks, got %v", files)
+	}
+}
+
+func TestSyncNotebooks(t *testing.T) {
+	source, err := ioutil.TempDir("", "notebook-sync")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(source)
+
+	local := map[string]interface{}{}
+	for _, name := range []string{"etl/load.py", "broken/a.py", "broken/b.py", "top.py"} {
+		p := filepath.Join(source, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte("print(1)"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		local[name] = "hash"
+	}
+
+	ok := func(req testAPIRequest) (int, interface{}) {
+		return http.StatusOK, map[string]interface{}{}
+	}
+
+	api := newTestAPI(map[string]testAPIHandler{
+		"GET /workspace/get-status": func(req testAPIRequest) (int, interface{}) {
+			if req.Query.Get("path") == "/Shared" {
+				return http.StatusOK, map[string]interface{}{"path": "/Shared", "object_type": "DIRECTORY"}
+			}
+			return http.StatusNotFound, map[string]interface{}{"error_code": "RESOURCE_DOES_NOT_EXIST"}
+		},
+		"POST /workspace/mkdirs": func(req testAPIRequest) (int, interface{}) {
+			if req.Body["path"] == "/Shared/sync/broken" {
+				return http.StatusForbidden, map[string]interface{}{"error_code": "PERMISSION_DENIED", "message": "denied"}
+			}
+			return http.StatusOK, map[string]interface{}{}
+		},
+		"POST /workspace/import": ok,
+	})
+	meta := testAPIMeta(t, api)
+
+	result, err := syncNotebooks(meta.StopContext, meta.Workspace, "/Shared/sync", source, map[string]interface{}{}, local, 2)
+	if err == nil {
+		t.Fatal("expected an error for the directory that could not be created")
+	}
+
+	var imported []string
+	for _, req := range api.find("POST /workspace/import") {
+		imported = append(imported, req.Body["path"].(string))
+	}
+	sort.Strings(imported)
+
+	if expected := []string{"/Shared/sync/etl/load", "/Shared/sync/top"}; !reflect.DeepEqual(imported, expected) {
+		t.Errorf("expected imports %v, got %v", expected, imported)
+	}
+
+	var directories []string
+	for _, dir := range result.Directories {
+		directories = append(directories, dir.(string))
+	}
+	sort.Strings(directories)
+
+	if expected := []string{"/Shared/sync", "/Shared/sync/etl"}; !reflect.DeepEqual(directories, expected) {
+		t.Errorf("expected created directories %v, got %v", expected, directories)
+	}
+}
+
+func TestResourceDatabricksNotebookSyncRead_html(t *testing.T) {
+	remote := "# Databricks notebook source\nprint(2)\n"
+	remoteHash := workspaceContentHash([]byte(remote))
+	remoteSha256 := hex.EncodeToString(remoteHash[:])
+
+	cases := []struct {
+		name     string
+		recorded string
+		expected string
+	}{
+		{"unchanged", remoteSha256, "local"},
+		{"changed", "previous", remoteSha256},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			api := newTestAPI(map[string]testAPIHandler{
+				"GET /workspace/list": func(req testAPIRequest) (int, interface{}) {
+					if req.Query.Get("path") != "/Shared/sync" {
+						return http.StatusOK, map[string]interface{}{}
+					}
+					return http.StatusOK, map[string]interface{}{
+						"objects": []map[string]interface{}{{"path": "/Shared/sync/report", "object_type": "NOTEBOOK", "language": "PYTHON"}},
+					}
+				},
+				"GET /workspace/export": func(req testAPIRequest) (int, interface{}) {
+					if format := req.Query.Get("format"); format != "SOURCE" {
+						t.Errorf("expected the notebook to be exported in SOURCE format, got %s", format)
+					}
+					return http.StatusOK, map[string]interface{}{
+						"content": base64.StdEncoding.EncodeToString([]byte(remote)),
+					}
+				},
+			})
+			meta := testAPIMeta(t, api)
+
+			d := schema.TestResourceDataRaw(t, resourceDatabricksNotebookSync().Schema, map[string]interface{}{
+				"path":   "/Shared/sync",
+				"source": "./notebooks",
+			})
+			d.SetId("/Shared/sync")
+			d.Set("files", map[string]interface{}{"report.html": "local"})
+			d.Set("exports", map[string]interface{}{"report.html": c.recorded})
+
+			if err := resourceDatabricksNotebookSyncRead(d, meta); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if actual := d.Get("files").(map[string]interface{})["report.html"]; actual != c.expected {
+				t.Errorf("expected checksum %q, got %q", c.expected, actual)
+			}
+
+			if actual := d.Get("exports").(map[string]interface{})["report.html"]; actual != c.recorded {
+				t.Errorf("expected the recorded export %q to be kept, got %q", c.recorded, actual)
+			}
+		})
+	}
+}
+
+func TestDeleteEmptyWorkspaceDirectories(t *testing.T) {
+	api := newTestAPI(map[string]testAPIHandler{
+		"GET /workspace/list": func(req testAPIRequest) (int, interface{}) {
+			if req.Query.Get("path") == "/Shared/sync/kept" {
+				return http.StatusOK, map[string]interface{}{
+					"objects": []map[string]interface{}{{"path": "/Shared/sync/kept/other", "object_type": "NOTEBOOK"}},
+				}
+			}
+			return http.StatusOK, map[string]interface{}{}
+		},
+		"POST /workspace/delete": func(req testAPIRequest) (int, interface{}) {
+			return http.StatusOK, map[string]interface{}{}
+		},
+	})
+	meta := testAPIMeta(t, api)
+
+	dirs := []string{"/Shared/sync", "/Shared/sync/etl/daily", "/Shared/sync/kept", "/Shared/sync/etl"}
+	if err := deleteEmptyWorkspaceDirectories(meta.StopContext, meta.Workspace, dirs); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	var deleted []string
+	for _, req := range api.find("POST /workspace/delete") {
+		deleted = append(deleted, req.Body["path"].(string))
+	}
+
+	if expected := []string{"/Shared/sync/etl/daily", "/Shared/sync/etl", "/Shared/sync"}; !reflect.DeepEqual(deleted, expected) {
+		t.Errorf("expected deletes %v, got %v", expected, deleted)
+	}
+}
+
+func testAccCheckDatabricksNotebookSyncDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "databricks_notebook_sync" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*Meta).Workspace
+		ctx := testAccProvider.Meta().(*Meta).StopContext
+
+		var notebooks []string
+		err := walkWorkspace(ctx, client, rs.Primary.ID, func(info workspace.ObjectInfo) error {
+			if info.ObjectType == workspace.NOTEBOOK {
+				notebooks = append(notebooks, to.String(info.Path))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(notebooks) > 0 {
+			return fmt.Errorf("Databricks notebooks still exist:\n%#v", notebooks)
+		}
+	}
+
+	return nil
+}
+
+func testAccDatabricksNotebookSyncBasic(path, source string) string {
+	return fmt.Sprintf(`
+resource "databricks_notebook_sync" "test" {
+  path   = "%s"
+  source = "%s"
+}
+`, path, source)
+}
@@ -0,0 +1,180 @@
+package databricks
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccDatabricksNotebook_basic(t *testing.T) {
+	resourceName := "databricks_notebook.test"
+	path := fmt.Sprintf("/Shared/%s/example", acctest.RandString(6))
+
+	dir, err := ioutil.TempDir("", "notebook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	source := filepath.Join(dir, "example.py")
+	if err := ioutil.WriteFile(source, []byte("print(\"Hello, world!\")\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDatabricksNotebookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabricksNotebookBasic(path, source),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "path", path),
+					resource.TestCheckResourceAttr(resourceName, "format", "SOURCE"),
+					resource.TestCheckResourceAttr(resourceName, "language", "PYTHON"),
+					resource.TestCheckResourceAttrSet(resourceName, "content_sha256"),
+					resource.TestCheckResourceAttrSet(resourceName, "object_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestNotebookSourceFormat(t *testing.T) {
+	cases := map[string]string{
+		"a/example.py":    "SOURCE/PYTHON",
+		"example.scala":   "SOURCE/SCALA",
+		"example.sql":     "SOURCE/SQL",
+		"example.R":       "SOURCE/R",
+		"example.ipynb":   "JUPYTER/",
+		"example.dbc":     "DBC/",
+		"example.html":    "HTML/",
+		"example.unknown": "",
+	}
+
+	for source, expected := range cases {
+		format, language, err := notebookSourceFormat(source)
+		if expected == "" {
+			if err == nil {
+				t.Errorf("%s: expected an error", source)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", source, err)
+			continue
+		}
+		if actual := fmt.Sprintf("%s/%s", format, language); actual != expected {
+			t.Errorf("%s: expected %s, got %s", source, expected, actual)
+		}
+	}
+}
+
+func TestResourceDatabricksNotebookRead(t *testing.T) {
+	remote := "# Databricks notebook source\nprint(2)\n"
+	remoteHash := workspaceContentHash([]byte(remote))
+	remoteSha256 := hex.EncodeToString(remoteHash[:])
+
+	cases := []struct {
+		name           string
+		source         string
+		exportSha256   string
+		expectedSha256 string
+		expectedExport string
+	}{
+		{"source", "example.py", "", remoteSha256, ""},
+		{"html unchanged", "example.html", remoteSha256, "local", remoteSha256},
+		{"html changed", "example.html", "previous", remoteSha256, "previous"},
+		{"html first refresh", "example.html", "", "local", remoteSha256},
+		{"jupyter changed", "example.ipynb", "previous", remoteSha256, "previous"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			api := newTestAPI(map[string]testAPIHandler{
+				"GET /workspace/get-status": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{
+						"path":        "/Shared/example",
+						"object_type": "NOTEBOOK",
+						"language":    "PYTHON",
+						"object_id":   123,
+					}
+				},
+				"GET /workspace/export": func(req testAPIRequest) (int, interface{}) {
+					if format := req.Query.Get("format"); format != "SOURCE" {
+						t.Errorf("expected the notebook to be exported in SOURCE format, got %s", format)
+					}
+					return http.StatusOK, map[string]interface{}{
+						"content": base64.StdEncoding.EncodeToString([]byte(remote)),
+					}
+				},
+			})
+			meta := testAPIMeta(t, api)
+
+			d := schema.TestResourceDataRaw(t, resourceDatabricksNotebook().Schema, map[string]interface{}{
+				"path":   "/Shared/example",
+				"source": c.source,
+			})
+			d.SetId("/Shared/example")
+			d.Set("content_sha256", "local")
+			d.Set("export_sha256", c.exportSha256)
+
+			if err := resourceDatabricksNotebookRead(d, meta); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if actual := d.Get("object_id").(string); actual != "123" {
+				t.Errorf("expected object_id 123, got %q", actual)
+			}
+
+			if actual := d.Get("content_sha256").(string); actual != c.expectedSha256 {
+				t.Errorf("expected content_sha256 %q, got %q", c.expectedSha256, actual)
+			}
+
+			if actual := d.Get("export_sha256").(string); actual != c.expectedExport {
+				t.Errorf("expected export_sha256 %q, got %q", c.expectedExport, actual)
+			}
+		})
+	}
+}
+
+func testAccCheckDatabricksNotebookDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "databricks_notebook" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*Meta).Workspace
+		ctx := testAccProvider.Meta().(*Meta).StopContext
+		resp, err := client.GetStatus(ctx, rs.Primary.ID)
+		if err != nil {
+			if resp.IsHTTPStatus(404) {
+				return nil
+			}
+			return err
+		}
+
+		return fmt.Errorf("Databricks notebook still exists:\n%#v", resp)
+	}
+
+	return nil
+}
+
+func testAccDatabricksNotebookBasic(path, source string) string {
+	return fmt.Sprintf(`
+resource "databricks_notebook" "test" {
+  path   = "%s"
+  source = "%s"
+}
+`, path, source)
+}
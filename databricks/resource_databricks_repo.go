@@ -0,0 +1,233 @@
+package databricks
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// gitProviders are the Git providers supported by repos and Git credentials.
+var gitProviders = []string{
+	"gitHub",
+	"gitHubEnterprise",
+	"bitbucketCloud",
+	"bitbucketServer",
+	"gitLab",
+	"gitLabEnterpriseEdition",
+	"azureDevOpsServices",
+	"awsCodeCommit",
+}
+
+func resourceDatabricksRepo() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDatabricksRepoCreate,
+		Read:   resourceDatabricksRepoRead,
+		Update: resourceDatabricksRepoUpdate,
+		Delete: resourceDatabricksRepoDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"git_provider": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(gitProviders, false),
+			},
+
+			"path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"branch": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"tag"},
+				ValidateFunc:  validation.StringIsNotEmpty,
+			},
+
+			"tag": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"branch"},
+				ValidateFunc:  validation.StringIsNotEmpty,
+			},
+
+			"default_branch": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"sparse_checkout_patterns": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"head_commit_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDatabricksRepoCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	attributes := repoCreateAttributes{
+		URL:            to.StringPtr(d.Get("url").(string)),
+		SparseCheckout: expandRepoSparseCheckout(d.Get("sparse_checkout_patterns").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("git_provider"); ok {
+		attributes.Provider = to.StringPtr(v.(string))
+	}
+
+	if v, ok := d.GetOk("path"); ok {
+		attributes.Path = to.StringPtr(v.(string))
+	}
+
+	resp, err := createRepo(ctx, client, attributes)
+	if err != nil {
+		return fmt.Errorf("unable to create repo: %s", err)
+	}
+
+	d.SetId(strconv.FormatInt(to.Int64(resp.ID), 10))
+	d.Set("default_branch", resp.Branch)
+
+	// The repo is cloned at the default branch, other branches and tags are
+	// checked out afterwards.
+	update := repoUpdateAttributes{}
+	if v, ok := d.GetOk("branch"); ok && v.(string) != to.String(resp.Branch) {
+		update.Branch = to.StringPtr(v.(string))
+	}
+	if v, ok := d.GetOk("tag"); ok {
+		update.Tag = to.StringPtr(v.(string))
+	}
+
+	if update.Branch != nil || update.Tag != nil {
+		if _, err := updateRepo(ctx, client, d.Id(), update); err != nil {
+			return fmt.Errorf("unable to check out repo: %s", err)
+		}
+	}
+
+	return resourceDatabricksRepoRead(d, meta)
+}
+
+func resourceDatabricksRepoRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	resp, err := getRepo(ctx, client, d.Id())
+	if err != nil {
+		if resp.IsHTTPStatus(404) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("unable to get repo: %s", err)
+	}
+
+	d.Set("url", resp.URL)
+	d.Set("git_provider", resp.Provider)
+	d.Set("path", resp.Path)
+
+	// A checked out tag is not on any branch.
+	if to.String(resp.Tag) != "" {
+		d.Set("branch", "")
+	} else {
+		d.Set("branch", resp.Branch)
+	}
+
+	d.Set("tag", resp.Tag)
+	d.Set("head_commit_id", resp.HeadCommitID)
+
+	var patterns []string
+	if resp.SparseCheckout != nil && resp.SparseCheckout.Patterns != nil {
+		patterns = *resp.SparseCheckout.Patterns
+	}
+	d.Set("sparse_checkout_patterns", patterns)
+
+	return nil
+}
+
+func resourceDatabricksRepoUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	attributes := repoUpdateAttributes{}
+
+	if d.HasChange("sparse_checkout_patterns") {
+		attributes.SparseCheckout = expandRepoSparseCheckout(d.Get("sparse_checkout_patterns").([]interface{}))
+		if attributes.SparseCheckout == nil {
+			attributes.SparseCheckout = &repoSparseCheckout{Patterns: &[]string{}}
+		}
+	}
+
+	// Checking out a branch or tag pulls its latest commit, so only the one
+	// that changed is sent. Removing the tag checks out the configured branch,
+	// or else the default branch the repo was cloned at.
+	if v, ok := d.GetOk("tag"); ok && d.HasChange("tag") {
+		attributes.Tag = to.StringPtr(v.(string))
+	} else if v, ok := d.GetOk("branch"); ok && (d.HasChange("branch") || d.HasChange("tag")) {
+		attributes.Branch = to.StringPtr(v.(string))
+	} else if d.HasChange("tag") {
+		branch := d.Get("default_branch").(string)
+		if branch == "" {
+			return fmt.Errorf("unable to check out the default branch of repo %s, as it is only known for repos created by Terraform. Set branch instead", d.Id())
+		}
+		attributes.Branch = to.StringPtr(branch)
+	}
+
+	if _, err := updateRepo(ctx, client, d.Id(), attributes); err != nil {
+		return fmt.Errorf("unable to update repo: %s", err)
+	}
+
+	return resourceDatabricksRepoRead(d, meta)
+}
+
+func resourceDatabricksRepoDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	resp, err := deleteRepo(ctx, client, d.Id())
+	if err != nil && !resp.IsHTTPStatus(404) {
+		return fmt.Errorf("unable to delete repo: %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func expandRepoSparseCheckout(input []interface{}) *repoSparseCheckout {
+	patterns := expandStringList(input)
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	return &repoSparseCheckout{
+		Patterns: &patterns,
+	}
+}
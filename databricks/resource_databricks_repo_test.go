@@ -0,0 +1,233 @@
+package databricks
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccDatabricksRepo_basic(t *testing.T) {
+	resourceName := "databricks_repo.test"
+	path := fmt.Sprintf("/Repos/Shared/%s", acctest.RandString(6))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDatabricksRepoDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabricksRepoBasic(path, "master"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "path", path),
+					resource.TestCheckResourceAttr(resourceName, "git_provider", "gitHub"),
+					resource.TestCheckResourceAttr(resourceName, "branch", "master"),
+					resource.TestCheckResourceAttrSet(resourceName, "head_commit_id"),
+				),
+			},
+			{
+				Config: testAccDatabricksRepoBasic(path, "gh-pages"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "branch", "gh-pages"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceDatabricksRepoUpdate(t *testing.T) {
+	state := map[string]string{
+		"url":                        "https://github.com/example/example.git",
+		"git_provider":               "gitHub",
+		"path":                       "/Repos/Shared/example",
+		"branch":                     "main",
+		"sparse_checkout_patterns.#": "0",
+	}
+
+	cases := []struct {
+		name     string
+		state    map[string]string
+		config   map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:     "sparse checkout",
+			config:   map[string]interface{}{"branch": "main", "sparse_checkout_patterns": []interface{}{"src"}},
+			expected: map[string]interface{}{"sparse_checkout": map[string]interface{}{"patterns": []interface{}{"src"}}},
+		},
+		{
+			name:     "branch",
+			config:   map[string]interface{}{"branch": "develop"},
+			expected: map[string]interface{}{"branch": "develop"},
+		},
+		{
+			name:     "tag",
+			state:    map[string]string{"tag": "v1"},
+			config:   map[string]interface{}{"tag": "v2"},
+			expected: map[string]interface{}{"tag": "v2"},
+		},
+		{
+			name:     "tag removed",
+			state:    map[string]string{"branch": "", "tag": "v1"},
+			config:   map[string]interface{}{"branch": "main"},
+			expected: map[string]interface{}{"branch": "main"},
+		},
+		{
+			name:     "tag removed without branch",
+			state:    map[string]string{"branch": "", "tag": "v1", "default_branch": "trunk"},
+			config:   map[string]interface{}{},
+			expected: map[string]interface{}{"branch": "trunk"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			api := newTestAPI(map[string]testAPIHandler{
+				"PATCH /repos/123": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{}
+				},
+				"GET /repos/123": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{"id": 123, "branch": "main"}
+				},
+			})
+			meta := testAPIMeta(t, api)
+
+			attributes := map[string]string{}
+			for k, v := range state {
+				attributes[k] = v
+			}
+			for k, v := range c.state {
+				attributes[k] = v
+			}
+
+			config := map[string]interface{}{"url": state["url"]}
+			for k, v := range c.config {
+				config[k] = v
+			}
+
+			d := testResourceDataUpdate(t, resourceDatabricksRepo(), "123", attributes, config)
+
+			if err := resourceDatabricksRepoUpdate(d, meta); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			requests := api.find("PATCH /repos/123")
+			if len(requests) != 1 {
+				t.Fatalf("expected one update, got %d", len(requests))
+			}
+
+			if !reflect.DeepEqual(requests[0].Body, c.expected) {
+				t.Errorf("expected:\n%#v\ngot:\n%#v", c.expected, requests[0].Body)
+			}
+		})
+	}
+}
+
+func TestResourceDatabricksRepoRead(t *testing.T) {
+	cases := []struct {
+		name         string
+		response     map[string]interface{}
+		expectBranch string
+		expectTag    string
+	}{
+		{
+			name:         "branch",
+			response:     map[string]interface{}{"id": 123, "branch": "main"},
+			expectBranch: "main",
+		},
+		{
+			name:      "tag",
+			response:  map[string]interface{}{"id": 123, "branch": "main", "tag": "v1"},
+			expectTag: "v1",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			api := newTestAPI(map[string]testAPIHandler{
+				"GET /repos/123": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, c.response
+				},
+			})
+			meta := testAPIMeta(t, api)
+
+			d := schema.TestResourceDataRaw(t, resourceDatabricksRepo().Schema, map[string]interface{}{
+				"url": "https://github.com/example/example.git",
+				"tag": "v0",
+			})
+			d.SetId("123")
+
+			if err := resourceDatabricksRepoRead(d, meta); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if actual := d.Get("branch").(string); actual != c.expectBranch {
+				t.Errorf("expected branch %q, got %q", c.expectBranch, actual)
+			}
+
+			if actual := d.Get("tag").(string); actual != c.expectTag {
+				t.Errorf("expected tag %q, got %q", c.expectTag, actual)
+			}
+		})
+	}
+}
+
+// testResourceDataUpdate returns the data of a resource with the given state
+// that is updated to config.
+func testResourceDataUpdate(t *testing.T, r *schema.Resource, id string, attributes map[string]string, config map[string]interface{}) *schema.ResourceData {
+	state := &terraform.InstanceState{ID: id, Attributes: attributes}
+
+	diff, err := r.Diff(state, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("unable to diff: %s", err)
+	}
+
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unable to create resource data: %s", err)
+	}
+
+	return d
+}
+
+func testAccCheckDatabricksRepoDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "databricks_repo" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*Meta).Workspace
+		ctx := testAccProvider.Meta().(*Meta).StopContext
+		resp, err := getRepo(ctx, client, rs.Primary.ID)
+		if err != nil {
+			if resp.IsHTTPStatus(404) {
+				return nil
+			}
+			return err
+		}
+
+		return fmt.Errorf("Databricks repo still exists:\n%#v", resp)
+	}
+
+	return nil
+}
+
+func testAccDatabricksRepoBasic(path, branch string) string {
+	return fmt.Sprintf(`
+resource "databricks_repo" "test" {
+  url    = "https://github.com/innovationnorway/terraform-provider-databricks.git"
+  path   = "%s"
+  branch = "%s"
+}
+`, path, branch)
+}
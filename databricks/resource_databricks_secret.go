@@ -2,8 +2,8 @@ package databricks
 
 import (
 	"fmt"
+	"strings"
 
-	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/innovationnorway/go-databricks/secrets"
@@ -15,6 +15,19 @@ func resourceDatabricksSecret() *schema.Resource {
 		Read:   resourceDatabricksSecretRead,
 		Delete: resourceDatabricksSecretDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceDatabricksSecretV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceDatabricksSecretStateUpgradeV0,
+				Version: 0,
+			},
+		},
+
 		Schema: map[string]*schema.Schema{
 			"scope": {
 				Type:         schema.TypeString,
@@ -73,9 +86,7 @@ func resourceDatabricksSecretCreate(d *schema.ResourceData, meta interface{}) er
 		return fmt.Errorf("unable to create secret: %s", err)
 	}
 
-	d.Set("scope", attributes.Scope)
-	d.Set("key", attributes.Key)
-	d.SetId(to.String(attributes.Scope) + "-" + to.String(attributes.Key))
+	d.SetId(getDatabricksSecretID(scope, key))
 
 	return resourceDatabricksSecretRead(d, meta)
 }
@@ -84,8 +95,10 @@ func resourceDatabricksSecretRead(d *schema.ResourceData, meta interface{}) erro
 	client := meta.(*Meta).Secrets
 	ctx := meta.(*Meta).StopContext
 
-	scope := d.Get("scope").(string)
-	key := d.Get("key").(string)
+	scope, key, err := parseDatabricksSecretID(d.Id())
+	if err != nil {
+		return err
+	}
 
 	attributes := secrets.ListSecretsAttributes{
 		Scope: &scope,
@@ -93,6 +106,10 @@ func resourceDatabricksSecretRead(d *schema.ResourceData, meta interface{}) erro
 
 	resp, err := client.List(ctx, attributes)
 	if err != nil {
+		if resp.IsHTTPStatus(404) {
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("unable to get key: %s", err)
 	}
 	if !isExistingSecret(key, resp.SecretsProperty) {
@@ -110,15 +127,17 @@ func resourceDatabricksSecretDelete(d *schema.ResourceData, meta interface{}) er
 	client := meta.(*Meta).Secrets
 	ctx := meta.(*Meta).StopContext
 
-	scope := d.Get("scope").(string)
-	key := d.Get("key").(string)
+	scope, key, err := parseDatabricksSecretID(d.Id())
+	if err != nil {
+		return err
+	}
 
 	attributes := secrets.Attributes{
 		Scope: &scope,
 		Key:   &key,
 	}
 
-	_, err := client.Delete(ctx, attributes)
+	_, err = client.Delete(ctx, attributes)
 	if err != nil {
 		return fmt.Errorf("unable to delete secret: %s", err)
 	}
@@ -141,3 +160,62 @@ func isExistingSecret(key string, secrets *[]secrets.MetadataAttributes) bool {
 
 	return false
 }
+
+// getDatabricksSecretID returns the ID of a secret. Scope names cannot
+// contain a slash, so the ID can always be split back into scope and key.
+func getDatabricksSecretID(scope, key string) string {
+	return fmt.Sprintf("%s/%s", scope, key)
+}
+
+func parseDatabricksSecretID(id string) (scope, key string, err error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unable to parse secret ID %q, expected <scope>/<key>", id)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+func resourceDatabricksSecretV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"scope": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"key": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"string_value": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+			"bytes_value": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+// resourceDatabricksSecretStateUpgradeV0 replaces the ambiguous
+// <scope>-<key> ID with one that can be parsed.
+func resourceDatabricksSecretStateUpgradeV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	scope, _ := rawState["scope"].(string)
+	key, _ := rawState["key"].(string)
+
+	if scope == "" || key == "" {
+		return nil, fmt.Errorf("unable to upgrade secret %q, scope or key is missing", rawState["id"])
+	}
+
+	rawState["id"] = getDatabricksSecretID(scope, key)
+
+	return rawState, nil
+}
@@ -2,8 +2,8 @@ package databricks
 
 import (
 	"fmt"
+	"strings"
 
-	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/innovationnorway/go-databricks/secrets"
@@ -15,6 +15,19 @@ func resourceDatabricksSecretAcl() *schema.Resource {
 		Read:   resourceDatabricksSecretAclRead,
 		Delete: resourceDatabricksSecretAclDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceDatabricksSecretAclV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceDatabricksSecretAclStateUpgradeV0,
+				Version: 0,
+			},
+		},
+
 		Schema: map[string]*schema.Schema{
 			"scope": {
 				Type:         schema.TypeString,
@@ -69,10 +82,7 @@ func resourceDatabricksSecretAclCreate(d *schema.ResourceData, meta interface{})
 		return fmt.Errorf("unable to create acl: %s", err)
 	}
 
-	d.Set("scope", attributes.Scope)
-	d.Set("principal", attributes.Principal)
-	d.Set("permission", string(attributes.Permission))
-	d.SetId(to.String(attributes.Scope) + "-" + to.String(attributes.Principal))
+	d.SetId(getDatabricksSecretAclID(scope, principal))
 
 	return resourceDatabricksSecretAclRead(d, meta)
 }
@@ -81,9 +91,10 @@ func resourceDatabricksSecretAclRead(d *schema.ResourceData, meta interface{}) e
 	client := meta.(*Meta).Secrets
 	ctx := meta.(*Meta).StopContext
 
-	scope := d.Get("scope").(string)
-	principal := d.Get("principal").(string)
-	permission := d.Get("permission").(string)
+	scope, principal, err := parseDatabricksSecretAclID(d.Id())
+	if err != nil {
+		return err
+	}
 
 	attributes := secrets.AclsAttributes{
 		Scope:     &scope,
@@ -101,7 +112,7 @@ func resourceDatabricksSecretAclRead(d *schema.ResourceData, meta interface{}) e
 
 	d.Set("scope", scope)
 	d.Set("principal", principal)
-	d.Set("permission", permission)
+	d.Set("permission", string(resp.Permission))
 
 	return nil
 }
@@ -110,15 +121,17 @@ func resourceDatabricksSecretAclDelete(d *schema.ResourceData, meta interface{})
 	client := meta.(*Meta).Secrets
 	ctx := meta.(*Meta).StopContext
 
-	scope := d.Get("scope").(string)
-	principal := d.Get("principal").(string)
+	scope, principal, err := parseDatabricksSecretAclID(d.Id())
+	if err != nil {
+		return err
+	}
 
 	attributes := secrets.AclsAttributes{
 		Scope:     &scope,
 		Principal: &principal,
 	}
 
-	_, err := client.DeleteAcls(ctx, attributes)
+	_, err = client.DeleteAcls(ctx, attributes)
 	if err != nil {
 		return fmt.Errorf("unable to remove acl: %s", err)
 	}
@@ -127,3 +140,55 @@ func resourceDatabricksSecretAclDelete(d *schema.ResourceData, meta interface{})
 
 	return nil
 }
+
+// getDatabricksSecretAclID returns the ID of a secret ACL. Scope names cannot
+// contain a slash, so the ID can always be split back into scope and principal.
+func getDatabricksSecretAclID(scope, principal string) string {
+	return fmt.Sprintf("%s/%s", scope, principal)
+}
+
+func parseDatabricksSecretAclID(id string) (scope, principal string, err error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unable to parse secret ACL ID %q, expected <scope>/<principal>", id)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+func resourceDatabricksSecretAclV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"scope": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"principal": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"permission": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+// resourceDatabricksSecretAclStateUpgradeV0 replaces the ambiguous
+// <scope>-<principal> ID with one that can be parsed.
+func resourceDatabricksSecretAclStateUpgradeV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	scope, _ := rawState["scope"].(string)
+	principal, _ := rawState["principal"].(string)
+
+	if scope == "" || principal == "" {
+		return nil, fmt.Errorf("unable to upgrade secret ACL %q, scope or principal is missing", rawState["id"])
+	}
+
+	rawState["id"] = getDatabricksSecretAclID(scope, principal)
+
+	return rawState, nil
+}
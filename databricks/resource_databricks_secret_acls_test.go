@@ -0,0 +1,40 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResourceDatabricksSecretAclStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"id":         "my-scope-data-engineers",
+		"scope":      "my-scope",
+		"principal":  "data-engineers",
+		"permission": "READ",
+	}
+
+	expected := map[string]interface{}{
+		"id":         "my-scope/data-engineers",
+		"scope":      "my-scope",
+		"principal":  "data-engineers",
+		"permission": "READ",
+	}
+
+	actual, err := resourceDatabricksSecretAclStateUpgradeV0(rawState, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected:\n%#v\ngot:\n%#v", expected, actual)
+	}
+
+	scope, principal, err := parseDatabricksSecretAclID(actual["id"].(string))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if scope != "my-scope" || principal != "data-engineers" {
+		t.Fatalf("unexpected scope %q and principal %q", scope, principal)
+	}
+}
@@ -104,11 +104,7 @@ func isExistingScope(scope string, scopes *[]secrets.ScopeAttributes) bool {
 		return false
 	}
 
-	print("DEBUG SCOPES")
-	print(scopes)
-
 	for _, item := range *scopes {
-		print(*item.Name)
 		if scope == *item.Name {
 			return true
 		}
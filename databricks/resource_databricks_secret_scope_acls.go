@@ -0,0 +1,299 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/innovationnorway/go-databricks/secrets"
+)
+
+func resourceDatabricksSecretScopeAcls() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDatabricksSecretScopeAclsCreate,
+		Read:   resourceDatabricksSecretScopeAclsRead,
+		Update: resourceDatabricksSecretScopeAclsUpdate,
+		Delete: resourceDatabricksSecretScopeAclsDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"scope": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"acl": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"principal": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"permission": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(secrets.READ),
+								string(secrets.WRITE),
+								string(secrets.MANAGE),
+							}, false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceDatabricksSecretScopeAclsCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Secrets
+	ctx := meta.(*Meta).StopContext
+
+	scope := d.Get("scope").(string)
+	acls := expandSecretScopeAcls(d.Get("acl").(*schema.Set).List())
+
+	caller, err := getSecretScopeAclsCaller(meta)
+	if err != nil {
+		return err
+	}
+
+	if err := applySecretScopeAcls(ctx, client, scope, acls, caller); err != nil {
+		return err
+	}
+
+	d.SetId(scope)
+
+	return resourceDatabricksSecretScopeAclsRead(d, meta)
+}
+
+func resourceDatabricksSecretScopeAclsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Secrets
+	ctx := meta.(*Meta).StopContext
+
+	scope := d.Id()
+
+	resp, err := listSecretAcls(ctx, client, scope)
+	if err != nil {
+		if resp.IsHTTPStatus(404) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("unable to list acls: %s", err)
+	}
+
+	d.Set("scope", scope)
+	d.Set("acl", flattenSecretScopeAcls(resp.Items))
+
+	return nil
+}
+
+func resourceDatabricksSecretScopeAclsUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Secrets
+	ctx := meta.(*Meta).StopContext
+
+	scope := d.Id()
+	acls := expandSecretScopeAcls(d.Get("acl").(*schema.Set).List())
+
+	caller, err := getSecretScopeAclsCaller(meta)
+	if err != nil {
+		return err
+	}
+
+	if err := applySecretScopeAcls(ctx, client, scope, acls, caller); err != nil {
+		return err
+	}
+
+	return resourceDatabricksSecretScopeAclsRead(d, meta)
+}
+
+func resourceDatabricksSecretScopeAclsDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Secrets
+	ctx := meta.(*Meta).StopContext
+
+	scope := d.Id()
+
+	caller, err := getSecretScopeAclsCaller(meta)
+	if err != nil {
+		return err
+	}
+
+	// The MANAGE permission of the caller is kept, as it is needed to remove
+	// the other ACLs and to delete the scope itself.
+	var principals []string
+	for principal, permission := range expandSecretScopeAcls(d.Get("acl").(*schema.Set).List()) {
+		if principal == caller && permission == string(secrets.MANAGE) {
+			continue
+		}
+		principals = append(principals, principal)
+	}
+	sort.Strings(principals)
+
+	for _, principal := range principals {
+		principal := principal
+
+		attributes := secrets.AclsAttributes{
+			Scope:     &scope,
+			Principal: &principal,
+		}
+
+		resp, err := client.DeleteAcls(ctx, attributes)
+		if err != nil {
+			if resp.IsHTTPStatus(404) {
+				continue
+			}
+			return fmt.Errorf("unable to remove acl for %q: %s", principal, err)
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// applySecretScopeAcls makes the ACLs of a scope match acls exactly. Grants
+// are written before anything is revoked, so a principal that keeps MANAGE
+// never loses access halfway through.
+func applySecretScopeAcls(ctx context.Context, client secrets.BaseClient, scope string, acls map[string]string, caller string) error {
+	resp, err := listSecretAcls(ctx, client, scope)
+	if err != nil {
+		return fmt.Errorf("unable to list acls: %s", err)
+	}
+
+	current := make(map[string]string)
+	if resp.Items != nil {
+		for _, item := range *resp.Items {
+			if item.Principal != nil {
+				current[*item.Principal] = string(item.Permission)
+			}
+		}
+	}
+
+	puts, deletes, err := diffSecretScopeAcls(current, acls, caller)
+	if err != nil {
+		return fmt.Errorf("unable to update acls of scope %q: %s", scope, err)
+	}
+
+	for _, principal := range puts {
+		principal := principal
+
+		attributes := secrets.PutSecretAclsAttributes{
+			Scope:      &scope,
+			Principal:  &principal,
+			Permission: secrets.Permission(acls[principal]),
+		}
+
+		if _, err := client.PutAcls(ctx, attributes); err != nil {
+			return fmt.Errorf("unable to put acl for %q: %s", principal, err)
+		}
+	}
+
+	for _, principal := range deletes {
+		principal := principal
+
+		attributes := secrets.AclsAttributes{
+			Scope:     &scope,
+			Principal: &principal,
+		}
+
+		if _, err := client.DeleteAcls(ctx, attributes); err != nil {
+			return fmt.Errorf("unable to remove acl for %q: %s", principal, err)
+		}
+	}
+
+	return nil
+}
+
+// diffSecretScopeAcls returns the principals whose ACL must be put and those
+// whose ACL must be removed to go from current to desired. It refuses to take
+// MANAGE away from caller, the principal the provider runs as, since the
+// scope could then no longer be managed.
+func diffSecretScopeAcls(current, desired map[string]string, caller string) ([]string, []string, error) {
+	manage := string(secrets.MANAGE)
+	if current[caller] == manage && desired[caller] != manage {
+		return nil, nil, fmt.Errorf("refusing to remove the MANAGE permission of %q, the principal the provider runs as", caller)
+	}
+
+	var puts, deletes []string
+
+	for principal, permission := range desired {
+		if current[principal] != permission {
+			puts = append(puts, principal)
+		}
+	}
+
+	for principal := range current {
+		if _, ok := desired[principal]; !ok {
+			deletes = append(deletes, principal)
+		}
+	}
+
+	sort.Strings(puts)
+	sort.Strings(deletes)
+
+	return puts, deletes, nil
+}
+
+// getSecretScopeAclsCaller returns the name of the principal the provider
+// runs as, as it appears in secret ACLs: the user name of a user, or the
+// application ID of a service principal.
+func getSecretScopeAclsCaller(meta interface{}) (string, error) {
+	resp, err := getScimMe(meta.(*Meta).StopContext, meta.(*Meta).Groups)
+	if err != nil {
+		return "", fmt.Errorf("unable to get current user: %s", err)
+	}
+
+	if resp.ApplicationID != nil && *resp.ApplicationID != "" {
+		return *resp.ApplicationID, nil
+	}
+
+	if resp.UserName != nil && *resp.UserName != "" {
+		return *resp.UserName, nil
+	}
+
+	return "", fmt.Errorf("unable to get current user: neither a user name nor an application ID was returned for %q", to.String(resp.ID))
+}
+
+func expandSecretScopeAcls(input []interface{}) map[string]string {
+	result := make(map[string]string, len(input))
+
+	for _, item := range input {
+		values := item.(map[string]interface{})
+		result[values["principal"].(string)] = values["permission"].(string)
+	}
+
+	return result
+}
+
+func flattenSecretScopeAcls(input *[]secrets.ACLItemAttributes) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	result := make([]interface{}, 0, len(*input))
+
+	for _, item := range *input {
+		values := make(map[string]interface{})
+
+		if item.Principal != nil {
+			values["principal"] = *item.Principal
+		}
+
+		values["permission"] = string(item.Permission)
+
+		result = append(result, values)
+	}
+
+	return result
+}
@@ -0,0 +1,206 @@
+package databricks
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccDatabricksSecretScopeAcls_basic(t *testing.T) {
+	resourceName := "databricks_secret_scope_acls.test"
+	scope := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDatabricksSecretScopeAclsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabricksSecretScopeAclsBasic(scope),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "scope", scope),
+					resource.TestCheckResourceAttr(resourceName, "acl.#", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestDiffSecretScopeAcls(t *testing.T) {
+	current := map[string]string{
+		"me@example.com": "MANAGE",
+		"users":          "READ",
+		"admins":         "WRITE",
+	}
+
+	desired := map[string]string{
+		"me@example.com": "MANAGE",
+		"users":          "WRITE",
+		"data-engineers": "READ",
+	}
+
+	puts, deletes, err := diffSecretScopeAcls(current, desired, "me@example.com")
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if expected := []string{"data-engineers", "users"}; !reflect.DeepEqual(puts, expected) {
+		t.Errorf("expected puts %v, got %v", expected, puts)
+	}
+
+	if expected := []string{"admins"}; !reflect.DeepEqual(deletes, expected) {
+		t.Errorf("expected deletes %v, got %v", expected, deletes)
+	}
+
+	if _, _, err := diffSecretScopeAcls(current, map[string]string{"me@example.com": "READ"}, "me@example.com"); err == nil {
+		t.Error("expected an error when the MANAGE permission of the caller is downgraded")
+	}
+
+	if _, _, err := diffSecretScopeAcls(current, map[string]string{}, "me@example.com"); err == nil {
+		t.Error("expected an error when the MANAGE permission of the caller is removed")
+	}
+
+	if _, _, err := diffSecretScopeAcls(current, map[string]string{}, "other@example.com"); err != nil {
+		t.Errorf("expected no error for another caller, got %s", err)
+	}
+}
+
+func TestGetSecretScopeAclsCaller(t *testing.T) {
+	cases := []struct {
+		name      string
+		me        map[string]interface{}
+		expected  string
+		expectErr bool
+	}{
+		{"user", map[string]interface{}{"id": "1", "userName": "me@example.com"}, "me@example.com", false},
+		{"service principal", map[string]interface{}{"id": "2", "applicationId": "00000000-1111-2222-3333-444444444444", "displayName": "ci"}, "00000000-1111-2222-3333-444444444444", false},
+		{"unknown", map[string]interface{}{"id": "3"}, "", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			api := newTestAPI(map[string]testAPIHandler{
+				"GET /preview/scim/v2/Me": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, c.me
+				},
+			})
+			meta := testAPIMeta(t, api)
+
+			actual, err := getSecretScopeAclsCaller(meta)
+			if c.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error, got caller %q", actual)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if actual != c.expected {
+				t.Errorf("expected caller %q, got %q", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestResourceDatabricksSecretScopeAclsDelete(t *testing.T) {
+	api := newTestAPI(map[string]testAPIHandler{
+		"GET /preview/scim/v2/Me": func(req testAPIRequest) (int, interface{}) {
+			return http.StatusOK, map[string]interface{}{"id": "1", "userName": "me@example.com"}
+		},
+		"POST /secrets/acls/delete": func(req testAPIRequest) (int, interface{}) {
+			return http.StatusOK, map[string]interface{}{}
+		},
+	})
+	meta := testAPIMeta(t, api)
+
+	d := schema.TestResourceDataRaw(t, resourceDatabricksSecretScopeAcls().Schema, map[string]interface{}{
+		"scope": "example",
+		"acl": []interface{}{
+			map[string]interface{}{"principal": "me@example.com", "permission": "MANAGE"},
+			map[string]interface{}{"principal": "users", "permission": "READ"},
+			map[string]interface{}{"principal": "admins", "permission": "MANAGE"},
+		},
+	})
+	d.SetId("example")
+
+	if err := resourceDatabricksSecretScopeAclsDelete(d, meta); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	var principals []string
+	for _, req := range api.find("POST /secrets/acls/delete") {
+		principals = append(principals, req.Body["principal"].(string))
+	}
+
+	if expected := []string{"admins", "users"}; !reflect.DeepEqual(principals, expected) {
+		t.Errorf("expected the ACLs of %v to be removed, got %v", expected, principals)
+	}
+
+	if d.Id() != "" {
+		t.Errorf("expected no ID, got %q", d.Id())
+	}
+}
+
+func testAccCheckDatabricksSecretScopeAclsDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "databricks_secret_scope_acls" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*Meta).Secrets
+		ctx := testAccProvider.Meta().(*Meta).StopContext
+		resp, err := listSecretAcls(ctx, client, rs.Primary.ID)
+		if err != nil {
+			if resp.IsHTTPStatus(404) {
+				return nil
+			}
+			return err
+		}
+
+		if resp.Items != nil && len(*resp.Items) > 0 {
+			return fmt.Errorf("Databricks secret scope ACLs still exist:\n%#v", resp.Items)
+		}
+	}
+
+	return nil
+}
+
+func testAccDatabricksSecretScopeAclsBasic(scope string) string {
+	return fmt.Sprintf(`
+data "databricks_group_members" "test" {
+  name = "admins"
+}
+
+resource "databricks_secret_scope" "test" {
+  scope = "%s"
+}
+
+resource "databricks_secret_scope_acls" "test" {
+  scope = databricks_secret_scope.test.scope
+
+  acl {
+    principal  = data.databricks_group_members.test.members[0].user_name
+    permission = "MANAGE"
+  }
+
+  acl {
+    principal  = "users"
+    permission = "READ"
+  }
+}
+`, scope)
+}
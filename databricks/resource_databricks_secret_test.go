@@ -0,0 +1,38 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResourceDatabricksSecretStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"id":    "my-scope-my-key",
+		"scope": "my-scope",
+		"key":   "my-key",
+	}
+
+	expected := map[string]interface{}{
+		"id":    "my-scope/my-key",
+		"scope": "my-scope",
+		"key":   "my-key",
+	}
+
+	actual, err := resourceDatabricksSecretStateUpgradeV0(rawState, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected:\n%#v\ngot:\n%#v", expected, actual)
+	}
+
+	scope, key, err := parseDatabricksSecretID(actual["id"].(string))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if scope != "my-scope" || key != "my-key" {
+		t.Fatalf("unexpected scope %q and key %q", scope, key)
+	}
+}
@@ -0,0 +1,200 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/innovationnorway/go-databricks/secrets"
+)
+
+func resourceDatabricksSecrets() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDatabricksSecretsCreate,
+		Read:   resourceDatabricksSecretsRead,
+		Update: resourceDatabricksSecretsUpdate,
+		Delete: resourceDatabricksSecretsDelete,
+
+		Schema: map[string]*schema.Schema{
+			"scope": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"secrets": {
+				Type:      schema.TypeMap,
+				Required:  true,
+				Sensitive: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"last_updated_timestamps": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceDatabricksSecretsCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Secrets
+	ctx := meta.(*Meta).StopContext
+
+	scope := d.Get("scope").(string)
+
+	for key, value := range d.Get("secrets").(map[string]interface{}) {
+		if err := putSecretString(ctx, client, scope, key, value.(string)); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(scope)
+
+	return resourceDatabricksSecretsRead(d, meta)
+}
+
+func resourceDatabricksSecretsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Secrets
+	ctx := meta.(*Meta).StopContext
+
+	scope := d.Id()
+
+	resp, err := client.List(ctx, secrets.ListSecretsAttributes{Scope: &scope})
+	if err != nil {
+		if resp.IsHTTPStatus(404) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("unable to list secrets: %s", err)
+	}
+
+	remote := make(map[string]string)
+	if resp.SecretsProperty != nil {
+		for _, item := range *resp.SecretsProperty {
+			if item.Key == nil {
+				continue
+			}
+			remote[*item.Key] = ""
+			if item.LastUpdatedTimestamp != nil {
+				remote[*item.Key] = strconv.FormatInt(*item.LastUpdatedTimestamp, 10)
+			}
+		}
+	}
+
+	// Secret values cannot be read back, so a key that has been deleted or
+	// rewritten since the last apply is dropped from state to force a write.
+	known := d.Get("last_updated_timestamps").(map[string]interface{})
+	managed := make(map[string]interface{})
+	timestamps := make(map[string]interface{})
+
+	for key, value := range d.Get("secrets").(map[string]interface{}) {
+		timestamp, ok := remote[key]
+		if !ok {
+			continue
+		}
+		if v, ok := known[key]; ok && v.(string) != timestamp {
+			continue
+		}
+		managed[key] = value
+		timestamps[key] = timestamp
+	}
+
+	d.Set("scope", scope)
+	d.Set("secrets", managed)
+	d.Set("last_updated_timestamps", timestamps)
+
+	return nil
+}
+
+func resourceDatabricksSecretsUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Secrets
+	ctx := meta.(*Meta).StopContext
+
+	scope := d.Id()
+
+	o, n := d.GetChange("secrets")
+	oldSecrets := o.(map[string]interface{})
+	newSecrets := n.(map[string]interface{})
+	timestamps := d.Get("last_updated_timestamps").(map[string]interface{})
+
+	for key, value := range newSecrets {
+		if v, ok := oldSecrets[key]; ok && v.(string) == value.(string) {
+			continue
+		}
+		if err := putSecretString(ctx, client, scope, key, value.(string)); err != nil {
+			return err
+		}
+		delete(timestamps, key)
+	}
+
+	for key := range oldSecrets {
+		if _, ok := newSecrets[key]; ok {
+			continue
+		}
+		if err := deleteSecret(ctx, client, scope, key); err != nil {
+			return err
+		}
+		delete(timestamps, key)
+	}
+
+	d.Set("last_updated_timestamps", timestamps)
+
+	return resourceDatabricksSecretsRead(d, meta)
+}
+
+func resourceDatabricksSecretsDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Secrets
+	ctx := meta.(*Meta).StopContext
+
+	scope := d.Id()
+
+	for key := range d.Get("secrets").(map[string]interface{}) {
+		if err := deleteSecret(ctx, client, scope, key); err != nil {
+			return err
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func putSecretString(ctx context.Context, client secrets.BaseClient, scope, key, value string) error {
+	attributes := secrets.Attributes{
+		Scope:       &scope,
+		Key:         &key,
+		StringValue: &value,
+	}
+
+	if _, err := client.Put(ctx, attributes); err != nil {
+		return fmt.Errorf("unable to put secret %q: %s", key, err)
+	}
+
+	return nil
+}
+
+func deleteSecret(ctx context.Context, client secrets.BaseClient, scope, key string) error {
+	attributes := secrets.Attributes{
+		Scope: &scope,
+		Key:   &key,
+	}
+
+	resp, err := client.Delete(ctx, attributes)
+	if err != nil {
+		if resp.IsHTTPStatus(404) {
+			return nil
+		}
+		return fmt.Errorf("unable to delete secret %q: %s", key, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package databricks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/innovationnorway/go-databricks/secrets"
+)
+
+func TestAccDatabricksSecrets_basic(t *testing.T) {
+	resourceName := "databricks_secrets.test"
+	scope := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDatabricksSecretsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabricksSecretsBasic(scope, "bar"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "scope", scope),
+					resource.TestCheckResourceAttr(resourceName, "secrets.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "last_updated_timestamps.%", "2"),
+				),
+			},
+			{
+				Config: testAccDatabricksSecretsBasic(scope, "baz"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "secrets.foo", "baz"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDatabricksSecretsDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "databricks_secrets" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*Meta).Secrets
+		ctx := testAccProvider.Meta().(*Meta).StopContext
+		resp, err := client.List(ctx, secrets.ListSecretsAttributes{Scope: &rs.Primary.ID})
+		if err != nil {
+			if resp.IsHTTPStatus(404) {
+				return nil
+			}
+			return err
+		}
+
+		if resp.SecretsProperty != nil && len(*resp.SecretsProperty) > 0 {
+			return fmt.Errorf("Databricks secrets still exist:\n%#v", resp.SecretsProperty)
+		}
+	}
+
+	return nil
+}
+
+func testAccDatabricksSecretsBasic(scope, value string) string {
+	return fmt.Sprintf(`
+resource "databricks_secret_scope" "test" {
+  scope = "%s"
+}
+
+resource "databricks_secrets" "test" {
+  scope = databricks_secret_scope.test.scope
+
+  secrets = {
+    foo   = "%s"
+    hello = "world"
+  }
+}
+`, scope, value)
+}
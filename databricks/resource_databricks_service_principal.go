@@ -0,0 +1,150 @@
+package databricks
+
+import (
+	"fmt"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceDatabricksServicePrincipal() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDatabricksServicePrincipalCreate,
+		Read:   resourceDatabricksServicePrincipalRead,
+		Update: resourceDatabricksServicePrincipalUpdate,
+		Delete: resourceDatabricksServicePrincipalDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"application_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"display_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"active": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"allow_cluster_create": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"allow_instance_pool_create": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"workspace_access": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDatabricksServicePrincipalCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Groups
+	ctx := meta.(*Meta).StopContext
+
+	attributes := scimServicePrincipal{
+		Schemas:      []string{scimServicePrincipalSchema},
+		Active:       to.BoolPtr(d.Get("active").(bool)),
+		Entitlements: expandScimEntitlements(d),
+	}
+
+	if v, ok := d.GetOk("application_id"); ok {
+		attributes.ApplicationID = to.StringPtr(v.(string))
+	}
+
+	if v, ok := d.GetOk("display_name"); ok {
+		attributes.DisplayName = to.StringPtr(v.(string))
+	}
+
+	resp, err := createScimServicePrincipal(ctx, client, attributes)
+	if err != nil {
+		return fmt.Errorf("unable to create service principal: %s", err)
+	}
+
+	d.SetId(to.String(resp.ID))
+
+	return resourceDatabricksServicePrincipalRead(d, meta)
+}
+
+func resourceDatabricksServicePrincipalRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Groups
+	ctx := meta.(*Meta).StopContext
+
+	resp, err := getScimServicePrincipal(ctx, client, d.Id())
+	if err != nil {
+		if resp.IsHTTPStatus(404) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("unable to get service principal: %s", err)
+	}
+
+	d.Set("application_id", resp.ApplicationID)
+	d.Set("display_name", resp.DisplayName)
+	d.Set("active", to.Bool(resp.Active))
+	flattenScimEntitlements(d, resp.Entitlements)
+
+	return nil
+}
+
+func resourceDatabricksServicePrincipalUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Groups
+	ctx := meta.(*Meta).StopContext
+
+	var operations []scimPatchOperation
+
+	if d.HasChange("display_name") {
+		operations = append(operations, scimPatchOperation{Op: "replace", Path: "displayName", Value: d.Get("display_name").(string)})
+	}
+
+	if d.HasChange("active") {
+		operations = append(operations, scimPatchOperation{Op: "replace", Path: "active", Value: d.Get("active").(bool)})
+	}
+
+	operations = append(operations, scimEntitlementPatchOperations(d)...)
+
+	if len(operations) > 0 {
+		if _, err := patchScimServicePrincipal(ctx, client, d.Id(), newScimPatchRequest(operations)); err != nil {
+			return fmt.Errorf("unable to update service principal: %s", err)
+		}
+	}
+
+	return resourceDatabricksServicePrincipalRead(d, meta)
+}
+
+func resourceDatabricksServicePrincipalDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Groups
+	ctx := meta.(*Meta).StopContext
+
+	resp, err := deleteScimServicePrincipal(ctx, client, d.Id())
+	if err != nil && !resp.IsHTTPStatus(404) {
+		return fmt.Errorf("unable to delete service principal: %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
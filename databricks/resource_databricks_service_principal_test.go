@@ -0,0 +1,77 @@
+package databricks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccDatabricksServicePrincipal_basic(t *testing.T) {
+	resourceName := "databricks_service_principal.test"
+	groupName := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDatabricksServicePrincipalDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabricksServicePrincipalBasic(groupName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "application_id"),
+					resource.TestCheckResourceAttr(resourceName, "display_name", "tf-"+groupName),
+					resource.TestCheckResourceAttr(resourceName, "allow_cluster_create", "true"),
+					resource.TestCheckResourceAttrPair("databricks_group_member.test", "service_principal_name", resourceName, "application_id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckDatabricksServicePrincipalDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "databricks_service_principal" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*Meta).Groups
+		ctx := testAccProvider.Meta().(*Meta).StopContext
+		resp, err := getScimServicePrincipal(ctx, client, rs.Primary.ID)
+		if err != nil {
+			if resp.IsHTTPStatus(404) {
+				return nil
+			}
+			return err
+		}
+
+		return fmt.Errorf("Databricks service principal still exists:\n%#v", resp)
+	}
+
+	return nil
+}
+
+func testAccDatabricksServicePrincipalBasic(groupName string) string {
+	return fmt.Sprintf(`
+resource "databricks_service_principal" "test" {
+  display_name         = "tf-%s"
+  allow_cluster_create = true
+}
+
+resource "databricks_group" "test" {
+  name = "%s"
+}
+
+resource "databricks_group_member" "test" {
+  parent_name            = databricks_group.test.name
+  service_principal_name = databricks_service_principal.test.application_id
+}
+`, groupName, groupName)
+}
@@ -0,0 +1,211 @@
+package databricks
+
+import (
+	"fmt"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceDatabricksUser() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDatabricksUserCreate,
+		Read:   resourceDatabricksUserRead,
+		Update: resourceDatabricksUserUpdate,
+		Delete: resourceDatabricksUserDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"display_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"active": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"allow_cluster_create": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"allow_instance_pool_create": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"workspace_access": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDatabricksUserCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Groups
+	ctx := meta.(*Meta).StopContext
+
+	attributes := scimUser{
+		Schemas:      []string{scimUserSchema},
+		UserName:     to.StringPtr(d.Get("user_name").(string)),
+		Active:       to.BoolPtr(d.Get("active").(bool)),
+		Entitlements: expandScimEntitlements(d),
+	}
+
+	if v, ok := d.GetOk("display_name"); ok {
+		attributes.DisplayName = to.StringPtr(v.(string))
+	}
+
+	resp, err := createScimUser(ctx, client, attributes)
+	if err != nil {
+		return fmt.Errorf("unable to create user: %s", err)
+	}
+
+	d.SetId(to.String(resp.ID))
+
+	return resourceDatabricksUserRead(d, meta)
+}
+
+func resourceDatabricksUserRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Groups
+	ctx := meta.(*Meta).StopContext
+
+	resp, err := getScimUser(ctx, client, d.Id())
+	if err != nil {
+		if resp.IsHTTPStatus(404) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("unable to get user: %s", err)
+	}
+
+	d.Set("user_name", resp.UserName)
+	d.Set("display_name", resp.DisplayName)
+	d.Set("active", to.Bool(resp.Active))
+	flattenScimEntitlements(d, resp.Entitlements)
+
+	return nil
+}
+
+func resourceDatabricksUserUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Groups
+	ctx := meta.(*Meta).StopContext
+
+	var operations []scimPatchOperation
+
+	if d.HasChange("display_name") {
+		operations = append(operations, scimPatchOperation{Op: "replace", Path: "displayName", Value: d.Get("display_name").(string)})
+	}
+
+	if d.HasChange("active") {
+		operations = append(operations, scimPatchOperation{Op: "replace", Path: "active", Value: d.Get("active").(bool)})
+	}
+
+	operations = append(operations, scimEntitlementPatchOperations(d)...)
+
+	if len(operations) > 0 {
+		if _, err := patchScimUser(ctx, client, d.Id(), newScimPatchRequest(operations)); err != nil {
+			return fmt.Errorf("unable to update user: %s", err)
+		}
+	}
+
+	return resourceDatabricksUserRead(d, meta)
+}
+
+func resourceDatabricksUserDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Groups
+	ctx := meta.(*Meta).StopContext
+
+	resp, err := deleteScimUser(ctx, client, d.Id())
+	if err != nil && !resp.IsHTTPStatus(404) {
+		return fmt.Errorf("unable to delete user: %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// scimEntitlements maps the boolean attributes of users, service principals
+// and groups to the SCIM entitlements they grant. Every resource using them
+// has all of these attributes.
+var scimEntitlements = []struct {
+	Attribute string
+	Value     string
+}{
+	{Attribute: "allow_cluster_create", Value: "allow-cluster-create"},
+	{Attribute: "allow_instance_pool_create", Value: "allow-instance-pool-create"},
+	{Attribute: "workspace_access", Value: "workspace-access"},
+}
+
+func expandScimEntitlements(d *schema.ResourceData) *[]scimValue {
+	result := make([]scimValue, 0)
+
+	for _, e := range scimEntitlements {
+		if v, ok := d.GetOk(e.Attribute); ok && v.(bool) {
+			result = append(result, scimValue{Value: to.StringPtr(e.Value)})
+		}
+	}
+
+	return &result
+}
+
+func flattenScimEntitlements(d *schema.ResourceData, input *[]scimValue) {
+	granted := make(map[string]bool)
+
+	if input != nil {
+		for _, item := range *input {
+			granted[to.String(item.Value)] = true
+		}
+	}
+
+	for _, e := range scimEntitlements {
+		d.Set(e.Attribute, granted[e.Value])
+	}
+}
+
+// scimEntitlementPatchOperations adds and removes the entitlements whose
+// attribute changed.
+func scimEntitlementPatchOperations(d *schema.ResourceData) []scimPatchOperation {
+	var result []scimPatchOperation
+
+	for _, e := range scimEntitlements {
+		if !d.HasChange(e.Attribute) {
+			continue
+		}
+
+		if d.Get(e.Attribute).(bool) {
+			result = append(result, scimPatchOperation{
+				Op:    "add",
+				Path:  "entitlements",
+				Value: []scimValue{{Value: to.StringPtr(e.Value)}},
+			})
+		} else {
+			result = append(result, scimPatchOperation{
+				Op:   "remove",
+				Path: fmt.Sprintf("entitlements[value eq %q]", e.Value),
+			})
+		}
+	}
+
+	return result
+}
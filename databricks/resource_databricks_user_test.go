@@ -0,0 +1,89 @@
+package databricks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccDatabricksUser_basic(t *testing.T) {
+	resourceName := "databricks_user.test"
+	userName := fmt.Sprintf("tf-%s@example.com", acctest.RandString(6))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDatabricksUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabricksUserBasic(userName, "Example", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "user_name", userName),
+					resource.TestCheckResourceAttr(resourceName, "display_name", "Example"),
+					resource.TestCheckResourceAttr(resourceName, "allow_cluster_create", "false"),
+					resource.TestCheckResourceAttrPair("data.databricks_user.test", "id", resourceName, "id"),
+					resource.TestCheckResourceAttr("data.databricks_user.test", "home", "/Users/"+userName),
+				),
+			},
+			{
+				Config: testAccDatabricksUserBasic(userName, "Example Updated", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "display_name", "Example Updated"),
+					resource.TestCheckResourceAttr(resourceName, "allow_cluster_create", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestScimEqualFilter(t *testing.T) {
+	expected := `userName eq "a\"b@example.com"`
+
+	if actual := scimEqualFilter("userName", `a"b@example.com`); actual != expected {
+		t.Fatalf("expected %s, got %s", expected, actual)
+	}
+}
+
+func testAccCheckDatabricksUserDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "databricks_user" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*Meta).Groups
+		ctx := testAccProvider.Meta().(*Meta).StopContext
+		resp, err := getScimUser(ctx, client, rs.Primary.ID)
+		if err != nil {
+			if resp.IsHTTPStatus(404) {
+				return nil
+			}
+			return err
+		}
+
+		return fmt.Errorf("Databricks user still exists:\n%#v", resp)
+	}
+
+	return nil
+}
+
+func testAccDatabricksUserBasic(userName, displayName string, allowClusterCreate bool) string {
+	return fmt.Sprintf(`
+resource "databricks_user" "test" {
+  user_name            = "%s"
+  display_name         = "%s"
+  allow_cluster_create = %t
+}
+
+data "databricks_user" "test" {
+  user_name = databricks_user.test.user_name
+}
+`, userName, displayName, allowClusterCreate)
+}
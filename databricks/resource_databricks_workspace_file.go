@@ -0,0 +1,196 @@
+package databricks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	pathpkg "path"
+	"strconv"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/innovationnorway/go-databricks/workspace"
+)
+
+// workspaceImportMaxSize is the largest base64 encoded content accepted by a
+// JSON import request.
+const workspaceImportMaxSize = 10 * 1024 * 1024
+
+func resourceDatabricksWorkspaceFile() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDatabricksWorkspaceFileCreate,
+		Read:   resourceDatabricksWorkspaceFileRead,
+		Update: resourceDatabricksWorkspaceFileUpdate,
+		Delete: resourceDatabricksWorkspaceFileDelete,
+
+		CustomizeDiff: resourceDatabricksWorkspaceFileCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"source": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"content_sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"object_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDatabricksWorkspaceFileCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	path := d.Get("path").(string)
+
+	parent := pathpkg.Dir(path)
+	if _, err := client.Mkdirs(ctx, workspace.MkdirsAttributes{Path: to.StringPtr(parent)}); err != nil {
+		return fmt.Errorf("unable to create directory %q: %s", parent, err)
+	}
+
+	if err := importWorkspaceFileFromSource(ctx, client, path, d.Get("source").(string), false); err != nil {
+		return err
+	}
+
+	d.SetId(path)
+
+	return resourceDatabricksWorkspaceFileRead(d, meta)
+}
+
+func resourceDatabricksWorkspaceFileRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	resp, err := client.GetStatus(ctx, d.Id())
+	if err != nil {
+		if resp.IsHTTPStatus(404) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("unable to get object status: %s", err)
+	}
+
+	hash := sha256.New()
+	if _, err := exportWorkspaceFile(ctx, client, d.Id(), workspaceAuto, hash); err != nil {
+		return fmt.Errorf("unable to export file: %s", err)
+	}
+
+	d.Set("path", resp.Path)
+	d.Set("content_sha256", hex.EncodeToString(hash.Sum(nil)))
+
+	if err := d.Set("object_id", strconv.FormatInt(to.Int64(resp.ObjectID), 10)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceDatabricksWorkspaceFileUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	if err := importWorkspaceFileFromSource(ctx, client, d.Id(), d.Get("source").(string), true); err != nil {
+		return err
+	}
+
+	return resourceDatabricksWorkspaceFileRead(d, meta)
+}
+
+func resourceDatabricksWorkspaceFileDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	attributes := workspace.DeleteAttributes{
+		Path: to.StringPtr(d.Id()),
+	}
+
+	resp, err := client.Delete(ctx, attributes)
+	if err != nil && !resp.IsHTTPStatus(404) {
+		return fmt.Errorf("unable to delete file: %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// resourceDatabricksWorkspaceFileCustomizeDiff hashes the local source file.
+// When it no longer matches the hash of the file exported during refresh, the
+// file is imported again.
+func resourceDatabricksWorkspaceFileCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("source") {
+		return nil
+	}
+
+	_, sha256Sum, err := hashLocalFile(d.Get("source").(string))
+	if err != nil {
+		return err
+	}
+
+	if d.Get("content_sha256").(string) == sha256Sum {
+		return nil
+	}
+
+	return d.SetNew("content_sha256", sha256Sum)
+}
+
+// importWorkspaceFileFromSource imports a local file in AUTO format. Files
+// that fit in a single JSON request are sent base64 encoded, larger files
+// are streamed.
+func importWorkspaceFileFromSource(ctx context.Context, client workspace.BaseClient, path, source string, overwrite bool) error {
+	f, err := os.Open(source)
+	if err != nil {
+		return fmt.Errorf("unable to open source: %s", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("unable to read source: %s", err)
+	}
+
+	if base64.StdEncoding.EncodedLen(int(info.Size())) > workspaceImportMaxSize {
+		if _, err := importWorkspaceFileStream(ctx, client, path, workspaceAuto, overwrite, f); err != nil {
+			return fmt.Errorf("unable to import file: %s", err)
+		}
+		return nil
+	}
+
+	content, err := ioutil.ReadAll(f)
+	if err != nil {
+		return fmt.Errorf("unable to read source: %s", err)
+	}
+
+	attributes := workspace.ImportAttributes{
+		Path:      to.StringPtr(path),
+		Format:    workspaceAuto,
+		Content:   to.StringPtr(base64.StdEncoding.EncodeToString(content)),
+		Overwrite: to.BoolPtr(overwrite),
+	}
+
+	if _, err := client.Import(ctx, attributes); err != nil {
+		return fmt.Errorf("unable to import file: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package databricks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccDatabricksWorkspaceFile_basic(t *testing.T) {
+	resourceName := "databricks_workspace_file.test"
+	path := fmt.Sprintf("/Shared/%s/requirements.txt", acctest.RandString(6))
+
+	dir, err := ioutil.TempDir("", "workspace-file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	source := filepath.Join(dir, "requirements.txt")
+	if err := ioutil.WriteFile(source, []byte("Hello, world!"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDatabricksWorkspaceFileDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabricksWorkspaceFileBasic(path, source),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "path", path),
+					resource.TestCheckResourceAttr(resourceName, "content_sha256", "315f5bdb76d078c43b8ac0064e4a0164612b1fce77c869345bfc94c75894edd3"),
+					resource.TestCheckResourceAttrSet(resourceName, "object_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceDatabricksWorkspaceFileRead(t *testing.T) {
+	api := newTestAPI(map[string]testAPIHandler{
+		"GET /workspace/get-status": func(req testAPIRequest) (int, interface{}) {
+			return http.StatusOK, map[string]interface{}{
+				"path":        req.Query.Get("path"),
+				"object_type": "FILE",
+				"object_id":   1234567890123,
+			}
+		},
+		"GET /workspace/export": func(req testAPIRequest) (int, interface{}) {
+			return http.StatusOK, "not a JSON object"
+		},
+	})
+	meta := testAPIMeta(t, api)
+
+	d := schema.TestResourceDataRaw(t, resourceDatabricksWorkspaceFile().Schema, map[string]interface{}{
+		"path":   "/Shared/example.txt",
+		"source": "example.txt",
+	})
+	d.SetId("/Shared/example.txt")
+
+	if err := resourceDatabricksWorkspaceFileRead(d, meta); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if actual := d.Get("object_id").(string); actual != "1234567890123" {
+		t.Errorf("expected object_id %q, got %q", "1234567890123", actual)
+	}
+
+	// The fake API encodes the raw content as JSON.
+	hash := sha256.Sum256([]byte("\"not a JSON object\"\n"))
+	if actual, expected := d.Get("content_sha256").(string), hex.EncodeToString(hash[:]); actual != expected {
+		t.Errorf("expected content_sha256 %q, got %q", expected, actual)
+	}
+}
+
+func testAccCheckDatabricksWorkspaceFileDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "databricks_workspace_file" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*Meta).Workspace
+		ctx := testAccProvider.Meta().(*Meta).StopContext
+		resp, err := client.GetStatus(ctx, rs.Primary.ID)
+		if err != nil {
+			if resp.IsHTTPStatus(404) {
+				return nil
+			}
+			return err
+		}
+
+		return fmt.Errorf("Databricks workspace file still exists:\n%#v", resp)
+	}
+
+	return nil
+}
+
+func testAccDatabricksWorkspaceFileBasic(path, source string) string {
+	return fmt.Sprintf(`
+resource "databricks_workspace_file" "test" {
+  path   = "%s"
+  source = "%s"
+}
+`, path, source)
+}
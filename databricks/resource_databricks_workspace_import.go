@@ -1,7 +1,17 @@
 package databricks
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"log"
+	"path"
+	"regexp"
+	"strconv"
 
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -57,20 +67,106 @@ func resourceDatabricksWorkspaceImport() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"export_sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"overwrite_policy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  workspaceImportOverwriteFail,
+				ValidateFunc: validation.StringInSlice([]string{
+					workspaceImportOverwriteFail,
+					workspaceImportOverwrite,
+					workspaceImportOverwriteAdoptIfIdentical,
+				}, false),
+			},
+
+			"on_move": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  workspaceImportOnMoveReport,
+				ValidateFunc: validation.StringInSlice([]string{
+					workspaceImportOnMoveReport,
+					workspaceImportOnMoveRestore,
+				}, false),
+			},
+
+			"move_search_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
 		},
 	}
 }
 
+const (
+	// workspaceImportOnMoveReport records the path the object was moved to,
+	// so the plan shows it being replaced at the configured path.
+	workspaceImportOnMoveReport = "report"
+	// workspaceImportOnMoveRestore moves the object back to the configured
+	// path on refresh.
+	workspaceImportOnMoveRestore = "restore"
+)
+
+const (
+	// workspaceImportOverwriteFail refuses to create the resource when an
+	// object exists at the path.
+	workspaceImportOverwriteFail = "fail"
+	// workspaceImportOverwrite replaces an existing notebook.
+	workspaceImportOverwrite = "overwrite"
+	// workspaceImportOverwriteAdoptIfIdentical manages an existing notebook
+	// if its content is identical to the configured content.
+	workspaceImportOverwriteAdoptIfIdentical = "adopt_if_identical"
+)
+
 func resourceDatabricksWorkspaceImportCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Meta).Workspace
 	ctx := meta.(*Meta).StopContext
 
 	path := d.Get("path").(string)
+	policy := d.Get("overwrite_policy").(string)
+
+	if format := d.Get("format").(string); policy == workspaceImportOverwriteAdoptIfIdentical && !workspaceContentComparable(format) {
+		return fmt.Errorf("unable to adopt notebooks in %s format, as their content cannot be compared. Set overwrite_policy to %q or %q", format, workspaceImportOverwriteFail, workspaceImportOverwrite)
+	}
 
 	attributes := workspace.ImportAttributes{
 		Path: &path,
 	}
 
+	existing, err := client.GetStatus(ctx, path)
+	if err != nil && !existing.IsHTTPStatus(404) {
+		return fmt.Errorf("unable to get object status: %s", err)
+	}
+
+	if err == nil {
+		switch {
+		case policy == workspaceImportOverwrite && existing.ObjectType == workspace.NOTEBOOK:
+			attributes.Overwrite = to.BoolPtr(true)
+
+		case policy == workspaceImportOverwriteAdoptIfIdentical && existing.ObjectType == workspace.NOTEBOOK:
+			identical, err := workspaceImportIsIdentical(d, meta)
+			if err != nil {
+				return err
+			}
+
+			if !identical {
+				return fmt.Errorf("unable to adopt %s, its content differs from the configured content", describeWorkspaceObject(existing))
+			}
+
+			d.SetId(path)
+
+			return resourceDatabricksWorkspaceImportRead(d, meta)
+
+		default:
+			return fmt.Errorf("unable to import object, %s already exists. Remove it, or set overwrite_policy to %q or %q", describeWorkspaceObject(existing), workspaceImportOverwrite, workspaceImportOverwriteAdoptIfIdentical)
+		}
+	}
+
 	if v, ok := d.GetOk("format"); ok {
 		attributes.Format = workspace.Format(v.(string))
 	}
@@ -83,13 +179,16 @@ func resourceDatabricksWorkspaceImportCreate(d *schema.ResourceData, meta interf
 		attributes.Content = to.StringPtr(v.(string))
 	}
 
-	_, err := client.Import(ctx, attributes)
-	if err != nil {
+	if _, err := client.Import(ctx, attributes); err != nil {
 		return fmt.Errorf("unable to import object: %s", err)
 	}
 
 	d.SetId(path)
 
+	if err := setWorkspaceImportExportHash(d, meta); err != nil {
+		return err
+	}
+
 	return resourceDatabricksWorkspaceImportRead(d, meta)
 }
 
@@ -101,16 +200,97 @@ func resourceDatabricksWorkspaceImportRead(d *schema.ResourceData, meta interfac
 
 	resp, err := client.GetStatus(ctx, path)
 	if err != nil {
-		if resp.IsHTTPStatus(404) {
+		if !resp.IsHTTPStatus(404) {
+			return fmt.Errorf("unable to get object status: %s", err)
+		}
+
+		moved, err := findMovedWorkspaceObject(ctx, client, workspaceImportMoveSearchPath(d), d.Get("object_id").(string))
+		if err != nil {
+			return err
+		}
+
+		if moved == nil {
+			log.Printf("[WARN] %q not found, removing from state", path)
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("unable to get object status: %s", err)
+
+		if d.Get("on_move").(string) != workspaceImportOnMoveRestore || moved.ObjectType != workspace.NOTEBOOK {
+			log.Printf("[WARN] %q was moved to %q outside of Terraform (object_id %s)", path, to.String(moved.Path), d.Get("object_id").(string))
+			d.Set("path", moved.Path)
+			return nil
+		}
+
+		log.Printf("[WARN] %q was moved to %q outside of Terraform (object_id %s), moving it back", path, to.String(moved.Path), d.Get("object_id").(string))
+
+		if err := restoreMovedWorkspaceObject(ctx, client, *moved, path); err != nil {
+			return err
+		}
+
+		resp, err = client.GetStatus(ctx, path)
+		if err != nil {
+			return fmt.Errorf("unable to get object status: %s", err)
+		}
 	}
 
 	d.Set("path", resp.Path)
 	d.Set("language", resp.Language)
-	d.Set("object_id", resp.ObjectID)
+
+	if err := d.Set("object_id", strconv.FormatInt(to.Int64(resp.ObjectID), 10)); err != nil {
+		return err
+	}
+
+	format := d.Get("format").(string)
+	if format == "" {
+		format = string(workspace.SOURCE)
+	}
+
+	// DBC archives are not reproducible, so their content is not compared.
+	if resp.ObjectType != workspace.NOTEBOOK || format == string(workspace.DBC) {
+		return nil
+	}
+
+	// HTML and JUPYTER exports never match the imported content. The SOURCE
+	// export is compared with the one made right after the last import
+	// instead, and any difference is planned as a change of content.
+	if !workspaceContentComparable(format) {
+		export, err := client.Export(ctx, path, string(workspace.SOURCE), nil)
+		if err != nil {
+			return fmt.Errorf("unable to export object: %s", err)
+		}
+
+		content, err := base64.StdEncoding.DecodeString(to.String(export.Content))
+		if err != nil {
+			return fmt.Errorf("unable to decode exported content: %s", err)
+		}
+
+		sum := workspaceContentHash(content)
+		hash := hex.EncodeToString(sum[:])
+
+		if recorded := d.Get("export_sha256").(string); recorded == "" {
+			d.Set("export_sha256", hash)
+		} else if recorded != hash {
+			d.Set("content", export.Content)
+		}
+
+		return nil
+	}
+
+	export, err := client.Export(ctx, path, format, nil)
+	if err != nil {
+		return fmt.Errorf("unable to export object: %s", err)
+	}
+
+	equal, err := workspaceContentEqual(format, to.String(export.Content), d.Get("content").(string))
+	if err != nil {
+		return err
+	}
+
+	// Only replace content when the notebook was changed out of band, so the
+	// configured content is not shown as changed on every plan.
+	if !equal {
+		d.Set("content", export.Content)
+	}
 
 	return nil
 }
@@ -143,6 +323,10 @@ func resourceDatabricksWorkspaceImportUpdate(d *schema.ResourceData, meta interf
 		return fmt.Errorf("unable to import object: %s", err)
 	}
 
+	if err := setWorkspaceImportExportHash(d, meta); err != nil {
+		return err
+	}
+
 	return resourceDatabricksWorkspaceImportRead(d, meta)
 }
 
@@ -165,3 +349,167 @@ func resourceDatabricksWorkspaceImportDelete(d *schema.ResourceData, meta interf
 
 	return nil
 }
+
+// setWorkspaceImportExportHash records the checksum of the SOURCE export of
+// a notebook that was just imported in HTML or JUPYTER format, so that
+// changes made outside of Terraform can be detected on refresh.
+func setWorkspaceImportExportHash(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	format := d.Get("format").(string)
+	if format == string(workspace.DBC) || workspaceContentComparable(format) {
+		return nil
+	}
+
+	hash, err := exportNotebookHash(ctx, client, d.Get("path").(string), workspace.SOURCE)
+	if err != nil {
+		return err
+	}
+
+	return d.Set("export_sha256", hash)
+}
+
+// workspaceImportIsIdentical compares the notebook at the configured path with
+// the configured content.
+func workspaceImportIsIdentical(d *schema.ResourceData, meta interface{}) (bool, error) {
+	client := meta.(*Meta).Workspace
+	ctx := meta.(*Meta).StopContext
+
+	format := d.Get("format").(string)
+	if format == "" {
+		format = string(workspace.SOURCE)
+	}
+
+	export, err := client.Export(ctx, d.Get("path").(string), format, nil)
+	if err != nil {
+		return false, fmt.Errorf("unable to export object: %s", err)
+	}
+
+	return workspaceContentEqual(format, to.String(export.Content), d.Get("content").(string))
+}
+
+// workspaceContentComparable reports whether a notebook exported in format
+// can be compared with the content it was imported from. Only SOURCE exports
+// round trip: HTML and JUPYTER exports add metadata, and DBC archives are
+// not reproducible.
+func workspaceContentComparable(format string) bool {
+	return format == "" || format == string(workspace.SOURCE)
+}
+
+// workspaceContentEqual compares base64 encoded content exported in format
+// with the configured content, after normalising both.
+func workspaceContentEqual(format, exported, content string) (bool, error) {
+	if !workspaceContentComparable(format) {
+		return false, fmt.Errorf("unable to compare the content of notebooks in %s format", format)
+	}
+
+	remote, err := base64.StdEncoding.DecodeString(exported)
+	if err != nil {
+		return false, fmt.Errorf("unable to decode exported content: %s", err)
+	}
+
+	local, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		return false, fmt.Errorf("unable to decode content: %s", err)
+	}
+
+	return workspaceContentHash(remote) == workspaceContentHash(local), nil
+}
+
+// describeWorkspaceObject names an object in error messages, e.g. the
+// PYTHON NOTEBOOK "/Shared/example" (object_id 123).
+func describeWorkspaceObject(info workspace.ObjectInfo) string {
+	kind := string(info.ObjectType)
+	if info.Language != nil {
+		kind = fmt.Sprintf("%s %s", *info.Language, kind)
+	}
+
+	return fmt.Sprintf("the %s %q (object_id %d)", kind, to.String(info.Path), to.Int64(info.ObjectID))
+}
+
+// workspaceImportMoveSearchPath returns the directory searched for a moved
+// notebook, which defaults to the directory the notebook was imported to.
+func workspaceImportMoveSearchPath(d *schema.ResourceData) string {
+	if v, ok := d.GetOk("move_search_path"); ok {
+		return v.(string)
+	}
+
+	return path.Dir(d.Get("path").(string))
+}
+
+// restoreMovedWorkspaceObject moves a notebook back to path. The API cannot
+// move objects, so the notebook is exported from where it was moved to,
+// imported at path, and the moved copy is deleted.
+func restoreMovedWorkspaceObject(ctx context.Context, client workspace.BaseClient, moved workspace.ObjectInfo, path string) error {
+	export, err := client.Export(ctx, to.String(moved.Path), string(workspace.SOURCE), nil)
+	if err != nil {
+		return fmt.Errorf("unable to export %s: %s", describeWorkspaceObject(moved), err)
+	}
+
+	attributes := workspace.ImportAttributes{
+		Path:     to.StringPtr(path),
+		Format:   workspace.SOURCE,
+		Language: workspace.Language(to.String(moved.Language)),
+		Content:  export.Content,
+	}
+
+	if _, err := client.Import(ctx, attributes); err != nil {
+		return fmt.Errorf("unable to import %s at %q: %s", describeWorkspaceObject(moved), path, err)
+	}
+
+	if _, err := client.Delete(ctx, workspace.DeleteAttributes{Path: moved.Path}); err != nil {
+		return fmt.Errorf("unable to delete %s: %s", describeWorkspaceObject(moved), err)
+	}
+
+	return nil
+}
+
+// errWorkspaceObjectFound stops the search of findMovedWorkspaceObject.
+var errWorkspaceObjectFound = errors.New("object found")
+
+// findMovedWorkspaceObject searches the directory root for the object with
+// the given ID. The API cannot look objects up by ID, so root is listed
+// recursively until the object is found. It returns nil if there is no such
+// object.
+func findMovedWorkspaceObject(ctx context.Context, client workspace.BaseClient, root, objectID string) (*workspace.ObjectInfo, error) {
+	if objectID == "" {
+		return nil, nil
+	}
+
+	id, err := strconv.ParseInt(objectID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse object_id %q: %s", objectID, err)
+	}
+
+	var result *workspace.ObjectInfo
+
+	err = walkWorkspace(ctx, client, root, func(info workspace.ObjectInfo) error {
+		if info.ObjectID != nil && *info.ObjectID == id {
+			result = &info
+			return errWorkspaceObjectFound
+		}
+		return nil
+	})
+	if err != nil && err != errWorkspaceObjectFound {
+		return nil, fmt.Errorf("unable to search for object %d: %s", id, err)
+	}
+
+	return result, nil
+}
+
+// workspaceSourceHeaderRegexp matches the header the workspace adds as the
+// first line of notebooks exported in SOURCE format.
+var workspaceSourceHeaderRegexp = regexp.MustCompile(`^(#|//|--) Databricks notebook source[ \t]*\r?\n`)
+
+// normalizeWorkspaceContent removes the differences between imported and
+// exported notebooks which are not edits: the source header and trailing
+// newlines.
+func normalizeWorkspaceContent(content []byte) []byte {
+	content = workspaceSourceHeaderRegexp.ReplaceAll(content, nil)
+	return bytes.TrimRight(content, "\r\n")
+}
+
+func workspaceContentHash(content []byte) [sha256.Size]byte {
+	return sha256.Sum256(normalizeWorkspaceContent(content))
+}
@@ -1,12 +1,19 @@
 package databricks
 
 import (
+	"encoding/base64"
 	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/innovationnorway/go-databricks/workspace"
 )
 
 func TestAccDatabricksWorkspaceImport_basic(t *testing.T) {
@@ -28,6 +35,504 @@ func TestAccDatabricksWorkspaceImport_basic(t *testing.T) {
 	})
 }
 
+func TestNormalizeWorkspaceContent(t *testing.T) {
+	cases := map[string]string{
+		"print(1)":     "print(1)",
+		"print(1)\n\n": "print(1)",
+		"# Databricks notebook source\nprint(1)\n":  "print(1)",
+		"// Databricks notebook source\nprintln(1)": "println(1)",
+		"-- Databricks notebook source\r\nSELECT 1": "SELECT 1",
+		"print(1)\n# Databricks notebook source\n":  "print(1)\n# Databricks notebook source",
+	}
+
+	for input, expected := range cases {
+		if actual := string(normalizeWorkspaceContent([]byte(input))); actual != expected {
+			t.Errorf("%q: expected %q, got %q", input, expected, actual)
+		}
+	}
+}
+
+func TestWorkspaceContentEqual(t *testing.T) {
+	encode := func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	}
+
+	cases := []struct {
+		format      string
+		exported    string
+		content     string
+		expected    bool
+		expectError bool
+	}{
+		{"", encode("# Databricks notebook source\nprint(1)\n"), encode("print(1)"), true, false},
+		{"SOURCE", encode("# Databricks notebook source\nprint(1)\n"), encode("print(1)\n\n"), true, false},
+		{"SOURCE", encode("# Databricks notebook source\nprint(2)\n"), encode("print(1)"), false, false},
+		{"SOURCE", "not base64", encode("print(1)"), false, true},
+		{"HTML", encode("<html></html>"), encode("<html></html>"), false, true},
+		{"JUPYTER", encode("{}"), encode("{}"), false, true},
+		{"DBC", encode("archive"), encode("archive"), false, true},
+	}
+
+	for _, c := range cases {
+		actual, err := workspaceContentEqual(c.format, c.exported, c.content)
+		if c.expectError {
+			if err == nil {
+				t.Errorf("%s %q: expected an error", c.format, c.exported)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %q: expected no error, got %s", c.format, c.exported, err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("%s %q: expected %t, got %t", c.format, c.exported, c.expected, actual)
+		}
+	}
+}
+
+func TestDescribeWorkspaceObject(t *testing.T) {
+	cases := []struct {
+		info     workspace.ObjectInfo
+		expected string
+	}{
+		{
+			info: workspace.ObjectInfo{
+				ObjectType: workspace.NOTEBOOK,
+				Path:       to.StringPtr("/Shared/example"),
+				Language:   to.StringPtr("PYTHON"),
+				ObjectID:   to.Int64Ptr(123),
+			},
+			expected: `the PYTHON NOTEBOOK "/Shared/example" (object_id 123)`,
+		},
+		{
+			info: workspace.ObjectInfo{
+				ObjectType: workspace.DIRECTORY,
+				Path:       to.StringPtr("/Shared/team-x"),
+				ObjectID:   to.Int64Ptr(456),
+			},
+			expected: `the DIRECTORY "/Shared/team-x" (object_id 456)`,
+		},
+	}
+
+	for _, c := range cases {
+		if actual := describeWorkspaceObject(c.info); actual != c.expected {
+			t.Errorf("expected %s, got %s", c.expected, actual)
+		}
+	}
+}
+
+func TestResourceDatabricksWorkspaceImportRead_moved(t *testing.T) {
+	cases := []struct {
+		name        string
+		onMove      string
+		searchPath  string
+		movedTo     string
+		expectID    string
+		expectPath  string
+		expectList  []string
+		expectCalls []string
+	}{
+		{
+			name:        "not found",
+			expectList:  []string{"/Shared/etl", "/Shared/etl/archive"},
+			expectCalls: []string{"GET /workspace/get-status", "GET /workspace/list", "GET /workspace/list"},
+		},
+		{
+			name:        "found",
+			movedTo:     "/Shared/etl/archive/renamed",
+			expectID:    "/Shared/etl/load",
+			expectPath:  "/Shared/etl/archive/renamed",
+			expectList:  []string{"/Shared/etl", "/Shared/etl/archive"},
+			expectCalls: []string{"GET /workspace/get-status", "GET /workspace/list", "GET /workspace/list"},
+		},
+		{
+			name:        "found restore",
+			onMove:      workspaceImportOnMoveRestore,
+			movedTo:     "/Shared/etl/renamed",
+			expectID:    "/Shared/etl/load",
+			expectPath:  "/Shared/etl/load",
+			expectList:  []string{"/Shared/etl"},
+			expectCalls: []string{"GET /workspace/get-status", "GET /workspace/list", "GET /workspace/export", "POST /workspace/import", "POST /workspace/delete", "GET /workspace/get-status", "GET /workspace/export"},
+		},
+		{
+			name:        "search path",
+			searchPath:  "/Shared",
+			movedTo:     "/Shared/moved",
+			expectID:    "/Shared/etl/load",
+			expectPath:  "/Shared/moved",
+			expectList:  []string{"/Shared"},
+			expectCalls: []string{"GET /workspace/get-status", "GET /workspace/list"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			objects := map[string][]map[string]interface{}{
+				"/Shared": {
+					{"path": "/Shared/etl", "object_type": "DIRECTORY", "object_id": 1},
+				},
+				"/Shared/etl": {
+					{"path": "/Shared/etl/other", "object_type": "NOTEBOOK", "object_id": 2},
+					{"path": "/Shared/etl/archive", "object_type": "DIRECTORY", "object_id": 3},
+				},
+			}
+
+			if c.movedTo != "" {
+				dir := c.movedTo[:strings.LastIndex(c.movedTo, "/")]
+				objects[dir] = append([]map[string]interface{}{
+					{"path": c.movedTo, "object_type": "NOTEBOOK", "language": "PYTHON", "object_id": 1234567890123},
+				}, objects[dir]...)
+			}
+
+			restored := false
+			content := base64.StdEncoding.EncodeToString([]byte("print(1)"))
+
+			api := newTestAPI(map[string]testAPIHandler{
+				"GET /workspace/get-status": func(req testAPIRequest) (int, interface{}) {
+					if !restored {
+						return http.StatusNotFound, map[string]interface{}{"error_code": "RESOURCE_DOES_NOT_EXIST"}
+					}
+					return http.StatusOK, map[string]interface{}{
+						"path":        req.Query.Get("path"),
+						"object_type": "NOTEBOOK",
+						"language":    "PYTHON",
+						"object_id":   1234567890124,
+					}
+				},
+				"GET /workspace/list": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{"objects": objects[req.Query.Get("path")]}
+				},
+				"GET /workspace/export": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{"content": content}
+				},
+				"POST /workspace/import": func(req testAPIRequest) (int, interface{}) {
+					restored = true
+					return http.StatusOK, map[string]interface{}{}
+				},
+				"POST /workspace/delete": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{}
+				},
+			})
+			meta := testAPIMeta(t, api)
+
+			raw := map[string]interface{}{
+				"path":    "/Shared/etl/load",
+				"content": content,
+			}
+			if c.onMove != "" {
+				raw["on_move"] = c.onMove
+			}
+			if c.searchPath != "" {
+				raw["move_search_path"] = c.searchPath
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceDatabricksWorkspaceImport().Schema, raw)
+			d.SetId("/Shared/etl/load")
+			d.Set("object_id", "1234567890123")
+
+			if err := resourceDatabricksWorkspaceImportRead(d, meta); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if d.Id() != c.expectID {
+				t.Errorf("expected ID %q, got %q", c.expectID, d.Id())
+			}
+
+			if c.expectID != "" {
+				if actual := d.Get("path").(string); actual != c.expectPath {
+					t.Errorf("expected path %q, got %q", c.expectPath, actual)
+				}
+			}
+
+			var listed []string
+			for _, req := range api.find("GET /workspace/list") {
+				listed = append(listed, req.Query.Get("path"))
+			}
+
+			if !reflect.DeepEqual(listed, c.expectList) {
+				t.Errorf("expected %v to be listed, got %v", c.expectList, listed)
+			}
+
+			if calls := api.calls(); !reflect.DeepEqual(calls, c.expectCalls) {
+				t.Errorf("expected calls %v, got %v", c.expectCalls, calls)
+			}
+
+			if exports := api.find("GET /workspace/export"); restored && exports[0].Query.Get("path") != c.movedTo {
+				t.Errorf("expected the moved copy %q to be exported, got %q", c.movedTo, exports[0].Query.Get("path"))
+			}
+
+			for _, req := range api.find("POST /workspace/import") {
+				if req.Body["path"] != "/Shared/etl/load" || req.Body["language"] != "PYTHON" || req.Body["format"] != "SOURCE" {
+					t.Errorf("expected a PYTHON notebook to be imported at the configured path, got %v", req.Body)
+				}
+			}
+
+			for _, req := range api.find("POST /workspace/delete") {
+				if req.Body["path"] != c.movedTo {
+					t.Errorf("expected the moved copy %q to be deleted, got %v", c.movedTo, req.Body["path"])
+				}
+			}
+		})
+	}
+}
+
+func TestResourceDatabricksWorkspaceImportRead_objectID(t *testing.T) {
+	api := newTestAPI(map[string]testAPIHandler{
+		"GET /workspace/get-status": func(req testAPIRequest) (int, interface{}) {
+			return http.StatusOK, map[string]interface{}{
+				"path":        req.Query.Get("path"),
+				"object_type": "NOTEBOOK",
+				"language":    "PYTHON",
+				"object_id":   1234567890123,
+			}
+		},
+		"GET /workspace/export": func(req testAPIRequest) (int, interface{}) {
+			return http.StatusOK, map[string]interface{}{
+				"content": base64.StdEncoding.EncodeToString([]byte("# Databricks notebook source\nprint(1)\n")),
+			}
+		},
+	})
+	meta := testAPIMeta(t, api)
+
+	content := base64.StdEncoding.EncodeToString([]byte("print(1)"))
+	d := schema.TestResourceDataRaw(t, resourceDatabricksWorkspaceImport().Schema, map[string]interface{}{
+		"path":    "/Shared/etl/load",
+		"content": content,
+	})
+	d.SetId("/Shared/etl/load")
+
+	if err := resourceDatabricksWorkspaceImportRead(d, meta); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if actual := d.Get("object_id").(string); actual != "1234567890123" {
+		t.Errorf("expected object_id %q, got %q", "1234567890123", actual)
+	}
+
+	if actual := d.Get("content").(string); actual != content {
+		t.Errorf("expected the configured content to be kept, got %q", actual)
+	}
+}
+
+func TestResourceDatabricksWorkspaceImportRead_html(t *testing.T) {
+	exported := []byte("# Databricks notebook source\nprint(1)\n")
+	sum := workspaceContentHash(exported)
+	hash := fmt.Sprintf("%x", sum)
+
+	content := base64.StdEncoding.EncodeToString([]byte("<html></html>"))
+
+	cases := []struct {
+		name          string
+		recorded      string
+		expectContent string
+		expectExport  string
+	}{
+		{
+			name:          "not recorded",
+			expectContent: content,
+			expectExport:  hash,
+		},
+		{
+			name:          "unchanged",
+			recorded:      hash,
+			expectContent: content,
+			expectExport:  hash,
+		},
+		{
+			name:          "changed",
+			recorded:      "0000",
+			expectContent: base64.StdEncoding.EncodeToString(exported),
+			expectExport:  "0000",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			api := newTestAPI(map[string]testAPIHandler{
+				"GET /workspace/get-status": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{
+						"path":        req.Query.Get("path"),
+						"object_type": "NOTEBOOK",
+						"language":    "PYTHON",
+						"object_id":   1234567890123,
+					}
+				},
+				"GET /workspace/export": func(req testAPIRequest) (int, interface{}) {
+					if format := req.Query.Get("format"); format != "SOURCE" {
+						t.Errorf("expected the notebook to be exported in SOURCE format, got %q", format)
+					}
+					return http.StatusOK, map[string]interface{}{
+						"content": base64.StdEncoding.EncodeToString(exported),
+					}
+				},
+			})
+			meta := testAPIMeta(t, api)
+
+			d := schema.TestResourceDataRaw(t, resourceDatabricksWorkspaceImport().Schema, map[string]interface{}{
+				"path":    "/Shared/etl/load",
+				"format":  "HTML",
+				"content": content,
+			})
+			d.SetId("/Shared/etl/load")
+			d.Set("export_sha256", c.recorded)
+
+			if err := resourceDatabricksWorkspaceImportRead(d, meta); err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if actual := d.Get("content").(string); actual != c.expectContent {
+				t.Errorf("expected content %q, got %q", c.expectContent, actual)
+			}
+
+			if actual := d.Get("export_sha256").(string); actual != c.expectExport {
+				t.Errorf("expected export_sha256 %q, got %q", c.expectExport, actual)
+			}
+		})
+	}
+}
+
+func TestResourceDatabricksWorkspaceImportCreate(t *testing.T) {
+	remote := base64.StdEncoding.EncodeToString([]byte("# Databricks notebook source\nprint(1)\n"))
+
+	cases := []struct {
+		name        string
+		policy      string
+		format      string
+		content     string
+		existing    string
+		expectErr   string
+		expectCalls []string
+		overwrite   bool
+	}{
+		{
+			name:        "missing",
+			policy:      workspaceImportOverwriteFail,
+			content:     "print(1)",
+			expectCalls: []string{"GET /workspace/get-status", "POST /workspace/import", "GET /workspace/get-status", "GET /workspace/export"},
+		},
+		{
+			name:        "fail",
+			policy:      workspaceImportOverwriteFail,
+			content:     "print(1)",
+			existing:    "NOTEBOOK",
+			expectErr:   `the PYTHON NOTEBOOK "/Shared/etl/load" (object_id 1234567890123) already exists`,
+			expectCalls: []string{"GET /workspace/get-status"},
+		},
+		{
+			name:        "overwrite",
+			policy:      workspaceImportOverwrite,
+			content:     "print(2)",
+			existing:    "NOTEBOOK",
+			expectCalls: []string{"GET /workspace/get-status", "POST /workspace/import", "GET /workspace/get-status", "GET /workspace/export"},
+			overwrite:   true,
+		},
+		{
+			name:        "overwrite directory",
+			policy:      workspaceImportOverwrite,
+			content:     "print(1)",
+			existing:    "DIRECTORY",
+			expectErr:   "already exists",
+			expectCalls: []string{"GET /workspace/get-status"},
+		},
+		{
+			name:        "adopt identical",
+			policy:      workspaceImportOverwriteAdoptIfIdentical,
+			content:     "print(1)",
+			existing:    "NOTEBOOK",
+			expectCalls: []string{"GET /workspace/get-status", "GET /workspace/export", "GET /workspace/get-status", "GET /workspace/export"},
+		},
+		{
+			name:        "adopt different",
+			policy:      workspaceImportOverwriteAdoptIfIdentical,
+			content:     "print(2)",
+			existing:    "NOTEBOOK",
+			expectErr:   "its content differs from the configured content",
+			expectCalls: []string{"GET /workspace/get-status", "GET /workspace/export"},
+		},
+		{
+			name:      "adopt html",
+			policy:    workspaceImportOverwriteAdoptIfIdentical,
+			format:    "HTML",
+			content:   "<html></html>",
+			existing:  "NOTEBOOK",
+			expectErr: "unable to adopt notebooks in HTML format",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			imported := false
+
+			api := newTestAPI(map[string]testAPIHandler{
+				"GET /workspace/get-status": func(req testAPIRequest) (int, interface{}) {
+					objectType := c.existing
+					if imported {
+						objectType = "NOTEBOOK"
+					}
+					if objectType == "" {
+						return http.StatusNotFound, map[string]interface{}{"error_code": "RESOURCE_DOES_NOT_EXIST"}
+					}
+					return http.StatusOK, map[string]interface{}{
+						"path":        req.Query.Get("path"),
+						"object_type": objectType,
+						"language":    "PYTHON",
+						"object_id":   1234567890123,
+					}
+				},
+				"GET /workspace/export": func(req testAPIRequest) (int, interface{}) {
+					return http.StatusOK, map[string]interface{}{"content": remote}
+				},
+				"POST /workspace/import": func(req testAPIRequest) (int, interface{}) {
+					imported = true
+					return http.StatusOK, map[string]interface{}{}
+				},
+			})
+			meta := testAPIMeta(t, api)
+
+			raw := map[string]interface{}{
+				"path":             "/Shared/etl/load",
+				"language":         "PYTHON",
+				"content":          base64.StdEncoding.EncodeToString([]byte(c.content)),
+				"overwrite_policy": c.policy,
+			}
+			if c.format != "" {
+				raw["format"] = c.format
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceDatabricksWorkspaceImport().Schema, raw)
+
+			err := resourceDatabricksWorkspaceImportCreate(d, meta)
+
+			if c.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.expectErr) {
+					t.Fatalf("expected error containing %q, got %v", c.expectErr, err)
+				}
+				if d.Id() != "" {
+					t.Errorf("expected no ID, got %q", d.Id())
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("expected no error, got %s", err)
+				}
+				if d.Id() != "/Shared/etl/load" {
+					t.Errorf("expected ID %q, got %q", "/Shared/etl/load", d.Id())
+				}
+			}
+
+			if calls := api.calls(); !reflect.DeepEqual(calls, c.expectCalls) && !(len(calls) == 0 && len(c.expectCalls) == 0) {
+				t.Errorf("expected calls %v, got %v", c.expectCalls, calls)
+			}
+
+			for _, req := range api.find("POST /workspace/import") {
+				if overwrite, _ := req.Body["overwrite"].(bool); overwrite != c.overwrite {
+					t.Errorf("expected overwrite %t, got %t", c.overwrite, overwrite)
+				}
+			}
+		})
+	}
+}
+
 func testAccCheckDatabricksWorkspaceImportDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "databricks_workspace_import" {
@@ -5,6 +5,7 @@ go 1.14
 require (
 	github.com/Azure/go-autorest/autorest v0.10.0
 	github.com/Azure/go-autorest/autorest/to v0.3.0
+	github.com/hashicorp/go-multierror v1.0.0
 	github.com/hashicorp/terraform v0.12.24
 	github.com/hashicorp/terraform-plugin-sdk v1.10.0
 	github.com/innovationnorway/go-azure v0.0.0-20200325011807-fc51476d2a64